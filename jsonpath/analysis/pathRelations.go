@@ -0,0 +1,442 @@
+package analysis
+
+import (
+	"github.com/CuiChao512/go-jsonpath/jsonpath/ast"
+	"github.com/CuiChao512/go-jsonpath/jsonpath/common"
+)
+
+// Answer is the answer to a question about paths that holds for all documents. Filters depend on the document, a
+// question that depends on a filter is answered MAYBE.
+type Answer int
+
+const (
+	NO    Answer = 0
+	MAYBE Answer = 1
+	YES   Answer = 2
+)
+
+func (a Answer) String() string {
+	switch a {
+	case NO:
+		return "NO"
+	case YES:
+		return "YES"
+	default:
+		return "MAYBE"
+	}
+}
+
+func and(a Answer, b Answer) Answer {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func or(a Answer, b Answer) Answer {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// Overlaps tells if there is a document in which a and b match the same location
+func Overlaps(a common.Path, b common.Path) (Answer, error) {
+	patternA, patternB, ok, err := patternsOf(a, b)
+	if err != nil || !ok {
+		return MAYBE, err
+	}
+	return (&overlap{a: patternA, b: patternB, memo: map[[2]int]Answer{}}).at(0, 0), nil
+}
+
+// Contains tells if a matches every location that b matches, in every document
+func Contains(a common.Path, b common.Path) (Answer, error) {
+	patternA, patternB, ok, err := patternsOf(a, b)
+	if err != nil || !ok {
+		return MAYBE, err
+	}
+	return contains(patternA, patternB), nil
+}
+
+// IsPrefix tells if every location that b matches is, or is inside of, a location that a matches, in every document.
+// $.users[*] is a prefix of $.users[0].name.
+func IsPrefix(a common.Path, b common.Path) (Answer, error) {
+	patternA, patternB, ok, err := patternsOf(a, b)
+	if err != nil || !ok {
+		return MAYBE, err
+	}
+	return contains(append(patternA, element{kind: elementScan}), patternB), nil
+}
+
+type elementKind int
+
+const (
+	elementStep elementKind = iota
+	elementScan
+	elementFilter
+)
+
+// element is one segment of a path seen as a pattern over the keys of a location. A step matches one key, a scan any
+// number of keys and a filter one array index or, with OPTION_FILTER_OBJECT_MEMBERS, one member name.
+type element struct {
+	kind    elementKind
+	segment ast.Segment
+}
+
+// patternsOf returns the patterns of two paths, ok is false if the paths can not be compared: they start at different
+// roots or contain functions or segments that move up the document.
+func patternsOf(a common.Path, b common.Path) ([]element, []element, bool, error) {
+	treeA, err := ast.FromCompiledPath(a)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	treeB, err := ast.FromCompiledPath(b)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	if treeA.RootToken() != treeB.RootToken() {
+		return nil, nil, false, nil
+	}
+	patternA, ok := patternOf(treeA)
+	if !ok {
+		return nil, nil, false, nil
+	}
+	patternB, ok := patternOf(treeB)
+	return patternA, patternB, ok, nil
+}
+
+func patternOf(tree *ast.Path) ([]element, bool) {
+	var pattern []element
+	for _, segment := range tree.Segments() {
+		switch segment.(type) {
+		case *ast.FieldSegment, *ast.WildcardSegment, *ast.IndexSegment, *ast.SliceSegment:
+			pattern = append(pattern, element{kind: elementStep, segment: segment})
+		case *ast.ScanSegment:
+			pattern = append(pattern, element{kind: elementScan})
+		case *ast.FilterSegment:
+			pattern = append(pattern, element{kind: elementFilter})
+		default:
+			return nil, false
+		}
+	}
+	return pattern, true
+}
+
+// overlap searches a location matched by both patterns, at(i, j) looks at the patterns from a[i] and b[j] on
+type overlap struct {
+	a    []element
+	b    []element
+	memo map[[2]int]Answer
+}
+
+func (o *overlap) at(i int, j int) Answer {
+	if answer, ok := o.memo[[2]int{i, j}]; ok {
+		return answer
+	}
+	answer := o.compute(i, j)
+	o.memo[[2]int{i, j}] = answer
+	return answer
+}
+
+func (o *overlap) compute(i int, j int) Answer {
+	if i == len(o.a) && j == len(o.b) {
+		return YES
+	}
+	answer := NO
+	if i < len(o.a) {
+		switch o.a[i].kind {
+		case elementScan:
+			answer = or(answer, o.at(i+1, j))
+			if j < len(o.b) {
+				answer = or(answer, and(anyKey(o.b[j]), o.at(i, j+1)))
+			}
+		case elementFilter:
+			answer = or(answer, and(MAYBE, o.at(i+1, j)))
+		}
+	}
+	if j < len(o.b) {
+		switch o.b[j].kind {
+		case elementScan:
+			answer = or(answer, o.at(i, j+1))
+			if i < len(o.a) {
+				answer = or(answer, and(anyKey(o.a[i]), o.at(i+1, j)))
+			}
+		case elementFilter:
+			answer = or(answer, and(MAYBE, o.at(i, j+1)))
+		}
+	}
+	if i < len(o.a) && j < len(o.b) && o.a[i].kind != elementScan && o.b[j].kind != elementScan {
+		answer = or(answer, and(intersects(o.a[i], o.b[j]), o.at(i+1, j+1)))
+	}
+	return answer
+}
+
+// anyKey tells if an element that is read by a scan can match a key
+func anyKey(e element) Answer {
+	switch e.kind {
+	case elementScan:
+		return YES
+	case elementFilter:
+		return MAYBE
+	}
+	if neverMatches(e.segment) {
+		return NO
+	}
+	return YES
+}
+
+// intersects tells if two steps or filters can match the same key
+func intersects(a element, b element) Answer {
+	if a.kind == elementFilter || b.kind == elementFilter {
+		// a filter may match any index or member name, depending on the document and the options
+		if (a.kind == elementStep && neverMatches(a.segment)) || (b.kind == elementStep && neverMatches(b.segment)) {
+			return NO
+		}
+		return MAYBE
+	}
+	if neverMatches(a.segment) || neverMatches(b.segment) {
+		return NO
+	}
+	_, wildcardA := a.segment.(*ast.WildcardSegment)
+	_, wildcardB := b.segment.(*ast.WildcardSegment)
+	if wildcardA || wildcardB {
+		return YES
+	}
+	fieldA, isFieldA := a.segment.(*ast.FieldSegment)
+	fieldB, isFieldB := b.segment.(*ast.FieldSegment)
+	if isFieldA && isFieldB {
+		for _, name := range fieldA.Names() {
+			if common.UtilsSliceContains(fieldB.Names(), name) {
+				return YES
+			}
+		}
+		return NO
+	}
+	if isFieldA || isFieldB {
+		return NO
+	}
+	for length := 0; length <= lengthBound(a.segment, b.segment); length++ {
+		indexesB := indexesOf(b.segment, length)
+		for index := range indexesOf(a.segment, length) {
+			if indexesB[index] {
+				return YES
+			}
+		}
+	}
+	return NO
+}
+
+// contains tells if pattern a matches every location pattern b matches
+func contains(a []element, b []element) Answer {
+	if neverMatchesAny(b) {
+		return YES
+	}
+	answer := (&cover{a: a, b: b, memo: map[[2]int]Answer{}}).at(0, 0)
+	if answer != NO || coverIsExact(a, b) {
+		return answer
+	}
+	return MAYBE
+}
+
+// coverIsExact tells if a cover that is not found means that b matches a location that a does not match. This is the
+// case when neither pattern has filters and a has no scans but at its end. It is also the case when b matches
+// locations at any depth, but a does not.
+func coverIsExact(a []element, b []element) bool {
+	if hasKind(a, elementFilter) || hasKind(b, elementFilter) {
+		return false
+	}
+	if hasKind(b, elementScan) {
+		return !hasKind(a, elementScan)
+	}
+	for i, e := range a {
+		if e.kind == elementScan && i != len(a)-1 {
+			return false
+		}
+	}
+	return true
+}
+
+func hasKind(pattern []element, kind elementKind) bool {
+	for _, e := range pattern {
+		if e.kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+func neverMatchesAny(pattern []element) bool {
+	for _, e := range pattern {
+		if e.kind == elementStep && neverMatches(e.segment) {
+			return true
+		}
+	}
+	return false
+}
+
+// cover searches a way for pattern a to match all the locations of pattern b. A found cover is certain, a cover that
+// is not found only means that b is not covered when coverIsExact says so.
+type cover struct {
+	a    []element
+	b    []element
+	memo map[[2]int]Answer
+}
+
+func (c *cover) at(i int, j int) Answer {
+	if answer, ok := c.memo[[2]int{i, j}]; ok {
+		return answer
+	}
+	answer := c.compute(i, j)
+	c.memo[[2]int{i, j}] = answer
+	return answer
+}
+
+func (c *cover) compute(i int, j int) Answer {
+	if i == len(c.a) {
+		if j == len(c.b) {
+			return YES
+		}
+		return NO
+	}
+	answer := NO
+	switch c.a[i].kind {
+	case elementScan:
+		answer = c.at(i+1, j)
+		if j < len(c.b) {
+			answer = or(answer, c.at(i, j+1))
+		}
+		return answer
+	case elementFilter:
+		answer = and(MAYBE, c.at(i+1, j))
+		if j < len(c.b) && c.b[j].kind != elementScan {
+			answer = or(answer, and(MAYBE, c.at(i+1, j+1)))
+		}
+		return answer
+	}
+	if j == len(c.b) {
+		return NO
+	}
+	switch c.b[j].kind {
+	case elementScan:
+		return NO
+	case elementFilter:
+		return and(MAYBE, or(c.at(i, j+1), c.at(i+1, j+1)))
+	}
+	return and(covers(c.a[i].segment, c.b[j].segment), c.at(i+1, j+1))
+}
+
+// covers tells if step a matches every key that step b matches, in every document
+func covers(a ast.Segment, b ast.Segment) Answer {
+	if _, wildcard := a.(*ast.WildcardSegment); wildcard || neverMatches(b) {
+		return YES
+	}
+	if _, wildcard := b.(*ast.WildcardSegment); wildcard {
+		return NO
+	}
+	fieldA, isFieldA := a.(*ast.FieldSegment)
+	fieldB, isFieldB := b.(*ast.FieldSegment)
+	if isFieldA && isFieldB {
+		for _, name := range fieldB.Names() {
+			if !common.UtilsSliceContains(fieldA.Names(), name) {
+				return NO
+			}
+		}
+		return YES
+	}
+	if isFieldA || isFieldB {
+		return NO
+	}
+	for length := 0; length <= lengthBound(a, b); length++ {
+		indexesA := indexesOf(a, length)
+		for index := range indexesOf(b, length) {
+			if !indexesA[index] {
+				return NO
+			}
+		}
+	}
+	return YES
+}
+
+// neverMatches tells if a step matches no key in any document, like the slice [2:1]
+func neverMatches(segment ast.Segment) bool {
+	if _, isSlice := segment.(*ast.SliceSegment); !isSlice {
+		return false
+	}
+	for length := 0; length <= lengthBound(segment, segment); length++ {
+		if len(indexesOf(segment, length)) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// lengthBound is the array length up to which the indexes of two steps are compared. Longer arrays give the same
+// answers: all positions of the steps are taken by then, counted from the start and from the end.
+func lengthBound(a ast.Segment, b ast.Segment) int {
+	bound := 0
+	for _, number := range append(numbersOf(a), numbersOf(b)...) {
+		if number < 0 {
+			number = -number
+		}
+		if number > bound {
+			bound = number
+		}
+	}
+	return 2*bound + 2
+}
+
+func numbersOf(segment ast.Segment) []int {
+	switch s := segment.(type) {
+	case *ast.IndexSegment:
+		return s.Indexes()
+	case *ast.SliceSegment:
+		from, _ := s.From()
+		to, _ := s.To()
+		return []int{from, to}
+	}
+	return nil
+}
+
+// indexesOf returns the indexes an index or slice step selects in an array of the given length, like the path tokens
+// do
+func indexesOf(segment ast.Segment, length int) map[int]bool {
+	indexes := map[int]bool{}
+	add := func(index int) {
+		if index < 0 {
+			index += length
+		}
+		if index >= 0 && index < length {
+			indexes[index] = true
+		}
+	}
+	switch s := segment.(type) {
+	case *ast.IndexSegment:
+		for _, index := range s.Indexes() {
+			add(index)
+		}
+	case *ast.SliceSegment:
+		from, hasFrom := s.From()
+		to, hasTo := s.To()
+		switch {
+		case hasFrom && !hasTo:
+			if from < 0 {
+				from += length
+			}
+			for i := common.UtilsMaxInt(0, from); i < length; i++ {
+				add(i)
+			}
+		case !hasFrom:
+			if to < 0 {
+				to += length
+			}
+			for i := 0; i < common.UtilsMinInt(length, to); i++ {
+				add(i)
+			}
+		default:
+			for i := from; i < common.UtilsMinInt(length, to); i++ {
+				add(i)
+			}
+		}
+	}
+	return indexes
+}
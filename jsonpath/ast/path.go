@@ -0,0 +1,235 @@
+package ast
+
+import (
+	"github.com/CuiChao512/go-jsonpath/jsonpath/common"
+	"github.com/CuiChao512/go-jsonpath/jsonpath/filter"
+	"github.com/CuiChao512/go-jsonpath/jsonpath/function"
+	pathPkg "github.com/CuiChao512/go-jsonpath/jsonpath/path"
+	"strings"
+)
+
+// Path is the syntax tree of a path: the root, $ or @, followed by segments. A Path is immutable, the builder methods
+// return a new Path:
+//
+//	ast.Root().Field("store").Field("book").Index(0)
+type Path struct {
+	root     string
+	segments []Segment
+}
+
+// Root starts a path at the document, $
+func Root() *Path {
+	return &Path{root: "$"}
+}
+
+// Current starts a path at the item tested by a filter, @
+func Current() *Path {
+	return &Path{root: "@"}
+}
+
+func CreatePath(root string, segments ...Segment) *Path {
+	return &Path{root: root, segments: append([]Segment(nil), segments...)}
+}
+
+func (p *Path) RootToken() string {
+	return p.root
+}
+
+func (p *Path) Segments() []Segment {
+	return append([]Segment(nil), p.segments...)
+}
+
+// Append returns a new path with the segments added to the end of this one
+func (p *Path) Append(segments ...Segment) *Path {
+	appended := make([]Segment, 0, len(p.segments)+len(segments))
+	appended = append(appended, p.segments...)
+	return &Path{root: p.root, segments: append(appended, segments...)}
+}
+
+func (p *Path) Field(names ...string) *Path {
+	return p.Append(CreateFieldSegment(names...))
+}
+
+func (p *Path) Wildcard() *Path {
+	return p.Append(CreateWildcardSegment())
+}
+
+func (p *Path) Scan() *Path {
+	return p.Append(CreateScanSegment())
+}
+
+func (p *Path) Index(indexes ...int) *Path {
+	return p.Append(CreateIndexSegment(indexes...))
+}
+
+func (p *Path) Slice(from int, to int) *Path {
+	return p.Append(CreateSliceSegment(from, to))
+}
+
+func (p *Path) SliceFrom(from int) *Path {
+	return p.Append(CreateSliceFromSegment(from))
+}
+
+func (p *Path) SliceTo(to int) *Path {
+	return p.Append(CreateSliceToSegment(to))
+}
+
+func (p *Path) Filter(predicates ...common.Predicate) *Path {
+	return p.Append(CreateFilterSegment(predicates...))
+}
+
+func (p *Path) Function(name string, parameters ...string) *Path {
+	return p.Append(CreateFunctionSegment(name, parameters...))
+}
+
+func (p *Path) Parent() *Path {
+	return p.Append(CreateParentSegment())
+}
+
+func (p *Path) PropertyName() *Path {
+	return p.Append(CreatePropertyNameSegment())
+}
+
+// Accept calls the visitor for every segment in order
+func (p *Path) Accept(visitor Visitor) error {
+	for _, segment := range p.segments {
+		if err := segment.Accept(visitor); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// String is the canonical form of the path, PathCompile reads it back to the same path
+func (p *Path) String() string {
+	return p.render(func(segment *FilterSegment) string {
+		return segment.String()
+	})
+}
+
+func (p *Path) render(filterString func(segment *FilterSegment) string) string {
+	sb := &strings.Builder{}
+	sb.WriteString(p.root)
+	for i, segment := range p.segments {
+		switch s := segment.(type) {
+		case *FilterSegment:
+			sb.WriteString(filterString(s))
+		case *FunctionSegment:
+			str := s.String()
+			// the scan already ends with a dot, $..length()
+			if _, scan := p.segmentAt(i - 1).(*ScanSegment); scan {
+				str = str[1:]
+			}
+			sb.WriteString(str)
+		default:
+			sb.WriteString(segment.String())
+		}
+	}
+	return sb.String()
+}
+
+func (p *Path) segmentAt(index int) Segment {
+	if index < 0 || index >= len(p.segments) {
+		return nil
+	}
+	return p.segments[index]
+}
+
+// Compile compiles the path. Filters are passed to the compiler as predicates, so the predicates of a FilterSegment
+// do not need to print as filter expressions.
+func (p *Path) Compile() (common.Path, error) {
+	var predicates []common.Predicate
+	pathString := p.render(func(segment *FilterSegment) string {
+		predicates = append(predicates, segment.predicates...)
+		return segment.placeholder()
+	})
+	// the compiler takes the predicates from the end of the list
+	filters := make([]common.Predicate, len(predicates))
+	for i, predicate := range predicates {
+		filters[len(predicates)-1-i] = predicate
+	}
+	return filter.PathCompileByStringAndPredicateSlice(pathString, filters)
+}
+
+// Parse compiles a path and returns its syntax tree
+func Parse(pathString string) (*Path, error) {
+	compiled, err := filter.PathCompile(pathString)
+	if err != nil {
+		return nil, err
+	}
+	return FromCompiledPath(compiled)
+}
+
+// FromCompiledPath returns the syntax tree of a compiled path
+func FromCompiledPath(compiled common.Path) (*Path, error) {
+	compiledPath, ok := compiled.(*pathPkg.CompiledPath)
+	if !ok {
+		return nil, &common.InvalidPathError{Message: "Only paths compiled by PathCompile have a syntax tree, got: " + compiled.String()}
+	}
+	root := compiledPath.GetRoot()
+	p := &Path{root: root.GetPathFragment()}
+	for token := root.GetNext(); token != nil; token = token.GetNext() {
+		segments, err := tokenSegments(token)
+		if err != nil {
+			return nil, err
+		}
+		p.segments = append(p.segments, segments...)
+	}
+	return p, nil
+}
+
+func tokenSegments(token pathPkg.Token) ([]Segment, error) {
+	switch t := token.(type) {
+	case *pathPkg.PropertyPathToken:
+		return []Segment{CreateFieldSegment(t.GetProperties()...)}, nil
+	case *pathPkg.WildcardPathToken:
+		return []Segment{CreateWildcardSegment()}, nil
+	case *pathPkg.ScanPathToken:
+		return []Segment{CreateScanSegment()}, nil
+	case *pathPkg.ArrayIndexPathToken:
+		return []Segment{CreateIndexSegment(t.GetArrayIndexOperation().Indexes()...)}, nil
+	case *pathPkg.ArraySlicePathToken:
+		operation := t.GetOperation()
+		switch operation.OperationType() {
+		case pathPkg.SLICE_FROM:
+			return []Segment{CreateSliceFromSegment(operation.From())}, nil
+		case pathPkg.SLICE_TO:
+			return []Segment{CreateSliceToSegment(operation.To())}, nil
+		default:
+			return []Segment{CreateSliceSegment(operation.From(), operation.To())}, nil
+		}
+	case *pathPkg.PredicatePathToken:
+		return []Segment{CreateFilterSegment(t.GetPredicates()...)}, nil
+	case *pathPkg.FunctionPathToken:
+		return functionSegments(t)
+	case *pathPkg.ParentPathToken:
+		return []Segment{CreateParentSegment()}, nil
+	case *pathPkg.PropertyNamePathToken:
+		return []Segment{CreatePropertyNameSegment()}, nil
+	}
+	return nil, &common.InvalidPathError{Message: "Path token " + token.GetPathFragment() + " has no syntax tree"}
+}
+
+func functionSegments(token *pathPkg.FunctionPathToken) ([]Segment, error) {
+	var segments []Segment
+	if token.GetSource() != nil {
+		source, err := FromCompiledPath(token.GetSource())
+		if err != nil {
+			return nil, err
+		}
+		segments = source.segments
+	}
+	parameters := make([]string, 0, len(token.GetParameters()))
+	for _, parameter := range token.GetParameters() {
+		if parameter.GetType() != function.PATH {
+			parameters = append(parameters, parameter.GetJson())
+			continue
+		}
+		parameterPath, err := FromCompiledPath(parameter.GetPath())
+		if err != nil {
+			return nil, err
+		}
+		parameters = append(parameters, parameterPath.String())
+	}
+	return append(segments, CreateFunctionSegment(token.GetFunctionName(), parameters...)), nil
+}
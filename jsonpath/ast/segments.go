@@ -0,0 +1,265 @@
+package ast
+
+import (
+	"github.com/CuiChao512/go-jsonpath/jsonpath/common"
+	"strconv"
+	"strings"
+)
+
+// Segment is one step of a Path. Segments are immutable, they are created with the Create* functions or the builder
+// methods of Path.
+type Segment interface {
+	Accept(visitor Visitor) error
+	String() string
+}
+
+// Visitor is called for every segment of a path by Path.Accept, an error stops the walk and is returned by Accept.
+type Visitor interface {
+	VisitField(segment *FieldSegment) error
+	VisitWildcard(segment *WildcardSegment) error
+	VisitScan(segment *ScanSegment) error
+	VisitIndex(segment *IndexSegment) error
+	VisitSlice(segment *SliceSegment) error
+	VisitFilter(segment *FilterSegment) error
+	VisitFunction(segment *FunctionSegment) error
+	VisitParent(segment *ParentSegment) error
+	VisitPropertyName(segment *PropertyNameSegment) error
+}
+
+// BaseVisitor does nothing for every segment, it is embedded by visitors that only look at some kinds of segments.
+type BaseVisitor struct{}
+
+func (BaseVisitor) VisitField(*FieldSegment) error               { return nil }
+func (BaseVisitor) VisitWildcard(*WildcardSegment) error         { return nil }
+func (BaseVisitor) VisitScan(*ScanSegment) error                 { return nil }
+func (BaseVisitor) VisitIndex(*IndexSegment) error               { return nil }
+func (BaseVisitor) VisitSlice(*SliceSegment) error               { return nil }
+func (BaseVisitor) VisitFilter(*FilterSegment) error             { return nil }
+func (BaseVisitor) VisitFunction(*FunctionSegment) error         { return nil }
+func (BaseVisitor) VisitParent(*ParentSegment) error             { return nil }
+func (BaseVisitor) VisitPropertyName(*PropertyNameSegment) error { return nil }
+
+// FieldSegment selects one or more properties, ['a'] or ['a','b']
+type FieldSegment struct {
+	names []string
+}
+
+func (s *FieldSegment) Names() []string {
+	return append([]string(nil), s.names...)
+}
+
+func (s *FieldSegment) Accept(visitor Visitor) error {
+	return visitor.VisitField(s)
+}
+
+func (s *FieldSegment) String() string {
+	quoted := make([]string, len(s.names))
+	for i, name := range s.names {
+		quoted[i] = quoteName(name)
+	}
+	return "[" + strings.Join(quoted, ",") + "]"
+}
+
+// quoteName quotes a property name so that the path compiler reads it back unchanged
+func quoteName(name string) string {
+	name = strings.ReplaceAll(name, "\\", "\\\\")
+	return "'" + strings.ReplaceAll(name, "'", "\\'") + "'"
+}
+
+func CreateFieldSegment(names ...string) *FieldSegment {
+	return &FieldSegment{names: append([]string(nil), names...)}
+}
+
+// WildcardSegment selects all properties or items, [*]
+type WildcardSegment struct{}
+
+func (s *WildcardSegment) Accept(visitor Visitor) error {
+	return visitor.VisitWildcard(s)
+}
+
+func (s *WildcardSegment) String() string {
+	return "[*]"
+}
+
+func CreateWildcardSegment() *WildcardSegment {
+	return &WildcardSegment{}
+}
+
+// ScanSegment applies the segment that follows it to the node and all its descendants, ..
+type ScanSegment struct{}
+
+func (s *ScanSegment) Accept(visitor Visitor) error {
+	return visitor.VisitScan(s)
+}
+
+func (s *ScanSegment) String() string {
+	return ".."
+}
+
+func CreateScanSegment() *ScanSegment {
+	return &ScanSegment{}
+}
+
+// IndexSegment selects one or more items of an array, [0] or [0,-1]
+type IndexSegment struct {
+	indexes []int
+}
+
+func (s *IndexSegment) Indexes() []int {
+	return append([]int(nil), s.indexes...)
+}
+
+func (s *IndexSegment) Accept(visitor Visitor) error {
+	return visitor.VisitIndex(s)
+}
+
+func (s *IndexSegment) String() string {
+	return "[" + common.UtilsJoin(",", "", s.indexes) + "]"
+}
+
+func CreateIndexSegment(indexes ...int) *IndexSegment {
+	return &IndexSegment{indexes: append([]int(nil), indexes...)}
+}
+
+// SliceSegment selects a range of items of an array, [1:], [:2] or [1:2]
+type SliceSegment struct {
+	from    int
+	to      int
+	hasFrom bool
+	hasTo   bool
+}
+
+func (s *SliceSegment) From() (int, bool) {
+	return s.from, s.hasFrom
+}
+
+func (s *SliceSegment) To() (int, bool) {
+	return s.to, s.hasTo
+}
+
+func (s *SliceSegment) Accept(visitor Visitor) error {
+	return visitor.VisitSlice(s)
+}
+
+func (s *SliceSegment) String() string {
+	sb := &strings.Builder{}
+	sb.WriteString("[")
+	if s.hasFrom {
+		sb.WriteString(strconv.Itoa(s.from))
+	}
+	sb.WriteString(":")
+	if s.hasTo {
+		sb.WriteString(strconv.Itoa(s.to))
+	}
+	sb.WriteString("]")
+	return sb.String()
+}
+
+func CreateSliceSegment(from int, to int) *SliceSegment {
+	return &SliceSegment{from: from, to: to, hasFrom: true, hasTo: true}
+}
+
+func CreateSliceFromSegment(from int) *SliceSegment {
+	return &SliceSegment{from: from, hasFrom: true}
+}
+
+func CreateSliceToSegment(to int) *SliceSegment {
+	return &SliceSegment{to: to, hasTo: true}
+}
+
+// FilterSegment selects the items that match all its predicates, [?(@.a > 1)]
+type FilterSegment struct {
+	predicates []common.Predicate
+}
+
+func (s *FilterSegment) Predicates() []common.Predicate {
+	return append([]common.Predicate(nil), s.predicates...)
+}
+
+func (s *FilterSegment) Accept(visitor Visitor) error {
+	return visitor.VisitFilter(s)
+}
+
+// String writes the predicates as one inline filter. Predicates print as filter expressions when they are compiled
+// filters or criteria.
+func (s *FilterSegment) String() string {
+	expressions := make([]string, len(s.predicates))
+	for i, predicate := range s.predicates {
+		expression := predicate.String()
+		if strings.HasPrefix(expression, "[?") && strings.HasSuffix(expression, "]") {
+			expression = expression[2 : len(expression)-1]
+		} else {
+			expression = "(" + expression + ")"
+		}
+		expressions[i] = expression
+	}
+	if len(expressions) == 1 {
+		return "[?" + expressions[0] + "]"
+	}
+	return "[?(" + strings.Join(expressions, " && ") + ")]"
+}
+
+func (s *FilterSegment) placeholder() string {
+	return "[" + strings.Repeat("?,", len(s.predicates)-1) + "?]"
+}
+
+func CreateFilterSegment(predicates ...common.Predicate) *FilterSegment {
+	return &FilterSegment{predicates: append([]common.Predicate(nil), predicates...)}
+}
+
+// FunctionSegment applies a function, .length() or .concat('-', @.id). Parameters are the text of json values or
+// paths.
+type FunctionSegment struct {
+	name       string
+	parameters []string
+}
+
+func (s *FunctionSegment) Name() string {
+	return s.name
+}
+
+func (s *FunctionSegment) Parameters() []string {
+	return append([]string(nil), s.parameters...)
+}
+
+func (s *FunctionSegment) Accept(visitor Visitor) error {
+	return visitor.VisitFunction(s)
+}
+
+func (s *FunctionSegment) String() string {
+	return "." + s.name + "(" + strings.Join(s.parameters, ", ") + ")"
+}
+
+func CreateFunctionSegment(name string, parameters ...string) *FunctionSegment {
+	return &FunctionSegment{name: name, parameters: append([]string(nil), parameters...)}
+}
+
+// ParentSegment selects the parent of the node, ^
+type ParentSegment struct{}
+
+func (s *ParentSegment) Accept(visitor Visitor) error {
+	return visitor.VisitParent(s)
+}
+
+func (s *ParentSegment) String() string {
+	return "^"
+}
+
+func CreateParentSegment() *ParentSegment {
+	return &ParentSegment{}
+}
+
+// PropertyNameSegment selects the property name or array index of the node, ~
+type PropertyNameSegment struct{}
+
+func (s *PropertyNameSegment) Accept(visitor Visitor) error {
+	return visitor.VisitPropertyName(s)
+}
+
+func (s *PropertyNameSegment) String() string {
+	return "~"
+}
+
+func CreatePropertyNameSegment() *PropertyNameSegment {
+	return &PropertyNameSegment{}
+}
@@ -2,6 +2,8 @@ package common
 
 import (
 	"fmt"
+	"strings"
+	"unicode"
 )
 
 const (
@@ -107,7 +109,7 @@ func (ci *CharacterIndex) IndexOfMatchingCloseChar(startPosition int, openChar r
 			}
 		}
 		if skipRegex {
-			if ci.CharAt(readPosition) == REGEX {
+			if ci.CharAt(readPosition) == REGEX && ci.isRegexStart(readPosition) {
 				readPosition = ci.NextIndexOfUnescapedFromStartPosition(readPosition, REGEX)
 				if readPosition == -1 {
 					return -1, &InvalidPathError{Message: "Could not find matching close quote for " + string(REGEX) + " when parsing regex in : " + ci.charSequence}
@@ -129,6 +131,18 @@ func (ci *CharacterIndex) IndexOfMatchingCloseChar(startPosition int, openChar r
 	return -1, nil
 }
 
+// isRegexStart tells a '/' opening a regex from a division, which follows an operand.
+func (ci *CharacterIndex) isRegexStart(position int) bool {
+	readPosition := position - 1
+	for readPosition >= 0 && ci.CharAt(readPosition) == SPACE {
+		readPosition--
+	}
+	if readPosition < 0 {
+		return true
+	}
+	return strings.ContainsRune("~=!<>(,&|", ci.CharAt(readPosition))
+}
+
 func (ci *CharacterIndex) IndexOfClosingBracket(startPosition int, skipStrings bool, skipRegex bool) (int, *InvalidPathError) {
 	return ci.IndexOfMatchingCloseChar(startPosition, OPEN_PARENTHESIS, CLOSE_PARENTHESIS, skipStrings, skipRegex)
 }
@@ -308,6 +322,33 @@ func (ci *CharacterIndex) IsNumberCharacter(readPosition int) bool {
 	return UtilsCharIsDigit(c) || c == MINUS || c == PERIOD || c == SCI_E || c == SCI_e
 }
 
+// IsFunctionCall tells if a function name followed by its parameters, like now(), starts at a position
+func (ci *CharacterIndex) IsFunctionCall(readPosition int) bool {
+	if !unicode.IsLetter(ci.CharAtOr(readPosition, SPACE)) {
+		return false
+	}
+	for ci.InBoundsByPosition(readPosition) && (unicode.IsLetter(ci.CharAt(readPosition)) || unicode.IsDigit(ci.CharAt(readPosition))) {
+		readPosition++
+	}
+	return ci.CharAtOr(readPosition, SPACE) == OPEN_PARENTHESIS
+}
+
+// VariableNameEnd is the position after the name of a variable, like userId in $userId, that starts at readPosition. It
+// is readPosition when no name starts there.
+func (ci *CharacterIndex) VariableNameEnd(readPosition int) int {
+	if char := ci.CharAtOr(readPosition, SPACE); !unicode.IsLetter(char) && char != '_' {
+		return readPosition
+	}
+	for ci.InBoundsByPosition(readPosition) {
+		char := ci.CharAt(readPosition)
+		if !unicode.IsLetter(char) && !unicode.IsDigit(char) && char != '_' {
+			break
+		}
+		readPosition++
+	}
+	return readPosition
+}
+
 func (ci *CharacterIndex) SkipBlanks() *CharacterIndex {
 	for ci.InBounds() && ci.position < ci.endPosition && ci.CurrentChar() == SPACE {
 		ci.IncrementPosition(1)
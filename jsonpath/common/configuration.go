@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"reflect"
 	"strconv"
+	"time"
 )
 
 type Option int
@@ -16,6 +17,13 @@ const (
 	OPTION_AS_PATH_LIST              Option = 2
 	OPTION_SUPPRESS_EXCEPTIONS       Option = 3
 	OPTION_REQUIRE_PROPERTIES        Option = 4
+	// OPTION_RFC9535 compiles paths with the RFC 9535 grammar and semantics instead of the Jayway dialect
+	OPTION_RFC9535 Option = 5
+	// OPTION_FILTER_OBJECT_MEMBERS makes a filter applied to an object test each member value, as RFC 9535 does,
+	// instead of the object itself
+	OPTION_FILTER_OBJECT_MEMBERS Option = 6
+	// OPTION_STRICT_NUMBERS makes numeric functions fail on an item that is not a number instead of skipping it
+	OPTION_STRICT_NUMBERS Option = 7
 )
 
 type Configuration struct {
@@ -23,6 +31,21 @@ type Configuration struct {
 	options             []Option
 	mappingProvider     MappingProvider
 	evaluationListeners []EvaluationListener
+	hmacKeys            map[string][]byte
+	clock               Clock
+	variables           map[string]interface{}
+	tracer              Tracer
+}
+
+// Clock tells the time to date functions like now()
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
 }
 
 func (c *Configuration) JsonProvider() JsonProvider {
@@ -33,6 +56,16 @@ func (c *Configuration) Options() []Option {
 	return c.options
 }
 
+// ContainsOption tells if the option is set, without the allocations of UtilsSliceContains
+func (c *Configuration) ContainsOption(option Option) bool {
+	for _, o := range c.options {
+		if o == option {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *Configuration) MappingProvider() MappingProvider {
 	return c.mappingProvider
 }
@@ -48,6 +81,75 @@ func (c *Configuration) AddOptions(options ...Option) *Configuration {
 	return c
 }
 
+// SetOptions creates a copy of the configuration with the given options instead of its own
+func (c *Configuration) SetOptions(options ...Option) *Configuration {
+	copied := *c
+	copied.options = options
+	return &copied
+}
+
+// SetEvaluationListeners creates a copy of the configuration with the given listeners instead of its own
+func (c *Configuration) SetEvaluationListeners(listeners ...EvaluationListener) *Configuration {
+	copied := *c
+	copied.evaluationListeners = listeners
+	return &copied
+}
+
+// AddHmacKey creates a copy of the configuration whose hmac() function can use the key under a name, so that paths
+// never contain the key itself
+func (c *Configuration) AddHmacKey(name string, key []byte) *Configuration {
+	copied := *c
+	copied.hmacKeys = make(map[string][]byte, len(c.hmacKeys)+1)
+	for n, k := range c.hmacKeys {
+		copied.hmacKeys[n] = k
+	}
+	copied.hmacKeys[name] = key
+	return &copied
+}
+
+func (c *Configuration) HmacKey(name string) ([]byte, bool) {
+	key, ok := c.hmacKeys[name]
+	return key, ok
+}
+
+// SetClock creates a copy of the configuration whose date functions use the given clock, which makes now()
+// predictable in tests
+func (c *Configuration) SetClock(clock Clock) *Configuration {
+	copied := *c
+	copied.clock = clock
+	return &copied
+}
+
+func (c *Configuration) Clock() Clock {
+	if c.clock == nil {
+		return systemClock{}
+	}
+	return c.clock
+}
+
+// SetVariables creates a copy of the configuration that binds the named variables of filters, like $userId
+func (c *Configuration) SetVariables(variables map[string]interface{}) *Configuration {
+	copied := *c
+	copied.variables = variables
+	return &copied
+}
+
+func (c *Configuration) Variable(name string) (interface{}, bool) {
+	value, ok := c.variables[name]
+	return value, ok
+}
+
+// SetTracer creates a copy of the configuration that sends the events of its evaluations to the tracer
+func (c *Configuration) SetTracer(tracer Tracer) *Configuration {
+	copied := *c
+	copied.tracer = tracer
+	return &copied
+}
+
+func (c *Configuration) Tracer() Tracer {
+	return c.tracer
+}
+
 type Empty struct {
 	empty bool
 }
@@ -101,7 +203,7 @@ func (*NativeJsonProvider) GetArrayIndex(obj interface{}, idx int) (interface{},
 	if !ok {
 		return nil, nil
 	}
-	if idx >= len(l) {
+	if idx < 0 || idx >= len(l) {
 		return nil, &IndexOutOfBoundError{Message: "GetArrayIndex error"}
 	}
 	return l[idx], nil
@@ -266,7 +368,7 @@ func (*NativeJsonProvider) Parse(jsonString string) (interface{}, error) {
 	var result interface{}
 	err := json.Unmarshal([]byte(jsonString), &result)
 	if err != nil {
-		return nil, err
+		return nil, &InvalidJsonError{Message: "Failed to parse json: " + err.Error(), ErrorDetails: ErrorDetails{Cause: err}}
 	}
 	return result, nil
 }
@@ -1,81 +1,416 @@
 package common
 
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrorCode is the stable code of an error, it does not change when the message of the error does
+type ErrorCode string
+
+const (
+	CodeInvalidPath         ErrorCode = "INVALID_PATH"
+	CodePathSyntax          ErrorCode = "PATH_SYNTAX"
+	CodeInvalidPattern      ErrorCode = "INVALID_PATTERN"
+	CodeInvalidJson         ErrorCode = "INVALID_JSON"
+	CodePathNotFound        ErrorCode = "PATH_NOT_FOUND"
+	CodeMissingProperty     ErrorCode = "MISSING_PROPERTY"
+	CodeNotAnObject         ErrorCode = "NOT_AN_OBJECT"
+	CodeNotAnArray          ErrorCode = "NOT_AN_ARRAY"
+	CodeEvaluationAborted   ErrorCode = "EVALUATION_ABORTED"
+	CodeInvalidModification ErrorCode = "INVALID_MODIFICATION"
+	CodeInvalidCriteria     ErrorCode = "INVALID_CRITERIA"
+	CodeValueCompare        ErrorCode = "VALUE_COMPARE"
+	CodeJsonPath            ErrorCode = "JSON_PATH"
+	CodeIllegalState        ErrorCode = "ILLEGAL_STATE"
+	CodeIndexOutOfBound     ErrorCode = "INDEX_OUT_OF_BOUND"
+)
+
+// Sentinel errors for errors.Is, an error is its own code and the code of its type. A PathNotFoundError with code
+// CodeMissingProperty is both ErrMissingProperty and ErrPathNotFound.
+var (
+	ErrInvalidPath         = errors.New("invalid path")
+	ErrPathSyntax          = errors.New("path syntax error")
+	ErrInvalidPattern      = errors.New("invalid pattern")
+	ErrInvalidJson         = errors.New("invalid json")
+	ErrPathNotFound        = errors.New("path not found")
+	ErrMissingProperty     = errors.New("missing property")
+	ErrNotAnObject         = errors.New("not an object")
+	ErrNotAnArray          = errors.New("not an array")
+	ErrEvaluationAborted   = errors.New("evaluation aborted")
+	ErrInvalidModification = errors.New("invalid modification")
+	ErrInvalidCriteria     = errors.New("invalid criteria")
+	ErrValueCompare        = errors.New("value compare error")
+	ErrJsonPath            = errors.New("json path error")
+	ErrIllegalState        = errors.New("illegal state")
+	ErrIndexOutOfBound     = errors.New("index out of bound")
+)
+
+var errorSentinels = map[ErrorCode]error{
+	CodeInvalidPath:         ErrInvalidPath,
+	CodePathSyntax:          ErrPathSyntax,
+	CodeInvalidPattern:      ErrInvalidPattern,
+	CodeInvalidJson:         ErrInvalidJson,
+	CodePathNotFound:        ErrPathNotFound,
+	CodeMissingProperty:     ErrMissingProperty,
+	CodeNotAnObject:         ErrNotAnObject,
+	CodeNotAnArray:          ErrNotAnArray,
+	CodeEvaluationAborted:   ErrEvaluationAborted,
+	CodeInvalidModification: ErrInvalidModification,
+	CodeInvalidCriteria:     ErrInvalidCriteria,
+	CodeValueCompare:        ErrValueCompare,
+	CodeJsonPath:            ErrJsonPath,
+	CodeIllegalState:        ErrIllegalState,
+	CodeIndexOutOfBound:     ErrIndexOutOfBound,
+}
+
+// CodedError is implemented by all errors of this package
+type CodedError interface {
+	error
+	Code() ErrorCode
+}
+
+// ErrorCodeOf returns the code of the first CodedError in the chain of err, or an empty code
+func ErrorCodeOf(err error) ErrorCode {
+	var coded CodedError
+	if errors.As(err, &coded) {
+		return coded.Code()
+	}
+	return ""
+}
+
+func codeOrDefault(code ErrorCode, defaultCode ErrorCode) ErrorCode {
+	if code == "" {
+		return defaultCode
+	}
+	return code
+}
+
+func isCodeSentinel(target error, code ErrorCode, defaultCode ErrorCode) bool {
+	return target == errorSentinels[code] || target == errorSentinels[defaultCode]
+}
+
+// ErrorDetails are the details that the errors of this package embed. ErrorCode is the code of the error when it is
+// more specific than the code of its type.
+type ErrorDetails struct {
+	// Path is the path that was evaluated, Location the normalized path where evaluation failed
+	Path      string
+	Location  string
+	ErrorCode ErrorCode
+	Cause     error
+}
+
+func (d *ErrorDetails) Unwrap() error {
+	return d.Cause
+}
+
+func (d *ErrorDetails) details() *ErrorDetails {
+	return d
+}
+
+func (d *ErrorDetails) code(typeCode ErrorCode) ErrorCode {
+	return codeOrDefault(d.ErrorCode, typeCode)
+}
+
+// is tells if the target is the sentinel of the code of the error or of the code of its type
+func (d *ErrorDetails) is(target error, typeCode ErrorCode) bool {
+	return isCodeSentinel(target, d.code(typeCode), typeCode)
+}
+
+// detailedError is an error of this package that embeds ErrorDetails
+type detailedError interface {
+	error
+	details() *ErrorDetails
+}
+
 type InvalidPathError struct {
 	Message string
+	ErrorDetails
 }
 
 func (e *InvalidPathError) Error() string {
 	return e.Message
 }
 
+func (e *InvalidPathError) Code() ErrorCode {
+	return e.code(CodeInvalidPath)
+}
+
+func (e *InvalidPathError) Is(target error) bool {
+	return e.is(target, CodeInvalidPath)
+}
+
 type InvalidJsonError struct {
 	Message string
+	ErrorDetails
 }
 
 func (e *InvalidJsonError) Error() string {
 	return e.Message
 }
 
+func (e *InvalidJsonError) Code() ErrorCode {
+	return e.code(CodeInvalidJson)
+}
+
+func (e *InvalidJsonError) Is(target error) bool {
+	return e.is(target, CodeInvalidJson)
+}
+
 type PathNotFoundError struct {
 	Message string
+	ErrorDetails
 }
 
 func (e *PathNotFoundError) Error() string {
 	return e.Message
 }
 
+func (e *PathNotFoundError) Code() ErrorCode {
+	return e.code(CodePathNotFound)
+}
+
+func (e *PathNotFoundError) Is(target error) bool {
+	return e.is(target, CodePathNotFound)
+}
+
 type EvaluationAbortError struct {
 	Message string
+	ErrorDetails
 }
 
 func (e *EvaluationAbortError) Error() string {
 	return e.Message
 }
 
+func (e *EvaluationAbortError) Code() ErrorCode {
+	return e.code(CodeEvaluationAborted)
+}
+
+func (e *EvaluationAbortError) Is(target error) bool {
+	return e.is(target, CodeEvaluationAborted)
+}
+
 type InvalidModificationError struct {
 	Message string
+	ErrorDetails
 }
 
 func (e *InvalidModificationError) Error() string {
 	return e.Message
 }
 
+func (e *InvalidModificationError) Code() ErrorCode {
+	return e.code(CodeInvalidModification)
+}
+
+func (e *InvalidModificationError) Is(target error) bool {
+	return e.is(target, CodeInvalidModification)
+}
+
 type InvalidCriteriaError struct {
 	Message string
+	ErrorDetails
 }
 
 func (e *InvalidCriteriaError) Error() string {
 	return e.Message
 }
 
+func (e *InvalidCriteriaError) Code() ErrorCode {
+	return e.code(CodeInvalidCriteria)
+}
+
+func (e *InvalidCriteriaError) Is(target error) bool {
+	return e.is(target, CodeInvalidCriteria)
+}
+
 type ValueCompareError struct {
 	Message string
+	ErrorDetails
 }
 
 func (e *ValueCompareError) Error() string {
 	return e.Message
 }
 
+func (e *ValueCompareError) Code() ErrorCode {
+	return e.code(CodeValueCompare)
+}
+
+func (e *ValueCompareError) Is(target error) bool {
+	return e.is(target, CodeValueCompare)
+}
+
 type JsonPathError struct {
 	Message string
+	ErrorDetails
 }
 
 func (e *JsonPathError) Error() string {
 	return e.Message
 }
 
+func (e *JsonPathError) Code() ErrorCode {
+	return e.code(CodeJsonPath)
+}
+
+func (e *JsonPathError) Is(target error) bool {
+	return e.is(target, CodeJsonPath)
+}
+
 type IllegalStateException struct {
 	Message string
+	ErrorDetails
 }
 
 func (e *IllegalStateException) Error() string {
 	return e.Message
 }
 
+func (e *IllegalStateException) Code() ErrorCode {
+	return e.code(CodeIllegalState)
+}
+
+func (e *IllegalStateException) Is(target error) bool {
+	return e.is(target, CodeIllegalState)
+}
+
 type IndexOutOfBoundError struct {
 	Message string
+	ErrorDetails
 }
 
 func (e *IndexOutOfBoundError) Error() string {
 	return e.Message
 }
+
+func (e *IndexOutOfBoundError) Code() ErrorCode {
+	return e.code(CodeIndexOutOfBound)
+}
+
+func (e *IndexOutOfBoundError) Is(target error) bool {
+	return e.is(target, CodeIndexOutOfBound)
+}
+
+// PathSyntaxError is the error of a path or filter that could not be compiled, it tells where the path is wrong.
+// Offset is the byte offset of the error in Path, Line and Column count characters from 1.
+type PathSyntaxError struct {
+	Message  string
+	Path     string
+	Offset   int
+	Line     int
+	Column   int
+	Token    string
+	Expected []string
+	Cause    error
+}
+
+func (e *PathSyntaxError) Error() string {
+	return e.Message
+}
+
+func (e *PathSyntaxError) Unwrap() error {
+	return e.Cause
+}
+
+func (e *PathSyntaxError) Code() ErrorCode {
+	return CodePathSyntax
+}
+
+// Position is the character position of the error in Path
+func (e *PathSyntaxError) Position() int {
+	return utf8.RuneCountInString(e.Path[:e.Offset])
+}
+
+// Pretty renders the error with the line of the path it is found in and a caret under the error:
+//
+//	Expected end of filter expression at line 1, column 14
+//	$[?(@.a == 1 2)]
+//	             ^
+func (e *PathSyntaxError) Pretty() string {
+	sb := &strings.Builder{}
+	sb.WriteString(e.Message + " at line " + strconv.Itoa(e.Line) + ", column " + strconv.Itoa(e.Column) + "\n")
+	line := strings.Split(e.Path, "\n")[e.Line-1]
+	sb.WriteString(line + "\n")
+	for i, char := range []rune(line) {
+		if i >= e.Column-1 {
+			break
+		}
+		if char == '\t' {
+			sb.WriteRune('\t')
+		} else {
+			sb.WriteRune(' ')
+		}
+	}
+	sb.WriteString("^")
+	if len(e.Expected) > 0 {
+		sb.WriteString("\nExpected: " + strings.Join(e.Expected, ", "))
+	}
+	return sb.String()
+}
+
+// CreateInvalidPathErrorBySyntaxError creates the error a path or filter that could not be compiled fails with, an
+// InvalidPathError whose cause is the PathSyntaxError that tells where the path is wrong
+func CreateInvalidPathErrorBySyntaxError(e *PathSyntaxError) *InvalidPathError {
+	return &InvalidPathError{Message: e.Message, ErrorDetails: ErrorDetails{Path: e.Path, ErrorCode: CodePathSyntax, Cause: e}}
+}
+
+// CreatePathSyntaxError creates the error found at a character position of a path
+func CreatePathSyntaxError(message string, path string, position int, expected ...string) *PathSyntaxError {
+	runes := []rune(path)
+	if position < 0 {
+		position = 0
+	} else if position > len(runes) {
+		position = len(runes)
+	}
+	line, column := 1, 1
+	for _, char := range runes[:position] {
+		if char == '\n' {
+			line++
+			column = 1
+		} else {
+			column++
+		}
+	}
+	end := position
+	for end < len(runes) && !strings.ContainsRune(" \t\r\n.[]()?,'\"", runes[end]) {
+		end++
+	}
+	if end == position && end < len(runes) {
+		end++
+	}
+	return &PathSyntaxError{
+		Message:  message,
+		Path:     path,
+		Offset:   len(string(runes[:position])),
+		Line:     line,
+		Column:   column,
+		Token:    string(runes[position:end]),
+		Expected: expected,
+	}
+}
+
+// ErrorWithPath returns an error of this package with the evaluated path, an error that does not have a path yet is
+// copied with it. Other errors are wrapped in a JsonPathError that keeps them as its cause.
+func ErrorWithPath(err error, path string) error {
+	switch e := err.(type) {
+	case nil:
+		return nil
+	case detailedError:
+		if e.details().Path != "" {
+			return err
+		}
+		copied := reflect.New(reflect.TypeOf(e).Elem())
+		copied.Elem().Set(reflect.ValueOf(e).Elem())
+		withPath := copied.Interface().(detailedError)
+		withPath.details().Path = path
+		return withPath
+	case CodedError:
+		return err
+	}
+	return &JsonPathError{Message: err.Error(), ErrorDetails: ErrorDetails{Path: path, Cause: err}}
+}
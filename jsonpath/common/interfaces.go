@@ -42,3 +42,14 @@ type PredicateContext interface {
 
 	Configuration() *Configuration
 }
+
+// ItemLocation describes where the item tested by a filter is found. Values that do not exist, like the parent of the
+// root document, are reported as JsonProviderUndefined.
+type ItemLocation interface {
+	Parent() interface{}
+	ParentProperty() interface{}
+	// Property is the property name or the array index of the item.
+	Property() interface{}
+	// Path is the path of the item, like $['store']['book'][0].
+	Path() string
+}
@@ -5,6 +5,7 @@ type PredicateContextImpl struct {
 	rootDocument      interface{}
 	configuration     *Configuration
 	documentPathCache map[Path]interface{}
+	location          ItemLocation
 }
 
 func (pc *PredicateContextImpl) Item() interface{} {
@@ -19,6 +20,11 @@ func (pc *PredicateContextImpl) Configuration() *Configuration {
 	return pc.configuration
 }
 
+// Location returns the location of the item in the document, nil if it is unknown.
+func (pc *PredicateContextImpl) Location() ItemLocation {
+	return pc.location
+}
+
 func (pc *PredicateContextImpl) Evaluate(path2 Path) (interface{}, error) {
 	var result interface{}
 	if path2.IsRootPath() {
@@ -55,3 +61,13 @@ func CreatePredicateContextImpl(contextDocument interface{}, rootDocument interf
 		documentPathCache: documentPathCache,
 	}
 }
+
+func CreatePredicateContextImplByLocation(contextDocument interface{}, rootDocument interface{}, configuration *Configuration, documentPathCache map[Path]interface{}, location ItemLocation) PredicateContext {
+	return &PredicateContextImpl{
+		contextDocument:   contextDocument,
+		rootDocument:      rootDocument,
+		configuration:     configuration,
+		documentPathCache: documentPathCache,
+		location:          location,
+	}
+}
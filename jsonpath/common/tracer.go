@@ -0,0 +1,49 @@
+package common
+
+type TraceEventKind int
+
+const (
+	// TRACE_TOKEN_ENTERED is sent when a token of the path is evaluated on a value
+	TRACE_TOKEN_ENTERED TraceEventKind = 0
+	// TRACE_PROPERTY_READ is sent when a property is read from an object, the value is JsonProviderUndefined if it is
+	// missing
+	TRACE_PROPERTY_READ TraceEventKind = 1
+	// TRACE_PREDICATE_EVALUATED is sent when a predicate of a filter has tested an item
+	TRACE_PREDICATE_EVALUATED TraceEventKind = 2
+	// TRACE_RESULT_ADDED is sent when a value is added to the results
+	TRACE_RESULT_ADDED TraceEventKind = 3
+)
+
+func (k TraceEventKind) String() string {
+	switch k {
+	case TRACE_TOKEN_ENTERED:
+		return "TOKEN_ENTERED"
+	case TRACE_PROPERTY_READ:
+		return "PROPERTY_READ"
+	case TRACE_PREDICATE_EVALUATED:
+		return "PREDICATE_EVALUATED"
+	case TRACE_RESULT_ADDED:
+		return "RESULT_ADDED"
+	}
+	return "UNKNOWN"
+}
+
+type TraceEvent struct {
+	Kind TraceEventKind
+	// Path is the path of the value, like $['store']['book'][0]
+	Path string
+	// Token is the path fragment of the token that sent the event, like ['book'] or [?]
+	Token string
+	Value interface{}
+	// Result is the result of the predicate for TRACE_PREDICATE_EVALUATED
+	Result bool
+	// Predicate and PredicateContext are the predicate and the context it was applied to for TRACE_PREDICATE_EVALUATED
+	Predicate        Predicate
+	PredicateContext PredicateContext
+}
+
+// Tracer receives the events of the evaluations of a configuration, which is useful to debug a path. Nothing is traced
+// when the configuration has no tracer.
+type Tracer interface {
+	Trace(event TraceEvent)
+}
@@ -1,11 +1,14 @@
 package common
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"reflect"
 	"strconv"
 	"strings"
+
+	"github.com/shopspring/decimal"
 )
 
 func UtilsJoin(delimiter string, warp string, values interface{}) string {
@@ -123,39 +126,85 @@ func UtilsIsInt(v interface{}) bool {
 
 }
 
+func UtilsIsUint(v interface{}) bool {
+	kind := reflect.ValueOf(v).Kind()
+
+	return kind == reflect.Uint || kind == reflect.Uint8 || kind == reflect.Uint16 || kind == reflect.Uint32 || kind == reflect.Uint64
+}
+
+// UtilsIsNumber tells if v is a Go integer or float, a json.Number or a decimal.Decimal
 func UtilsIsNumber(v interface{}) bool {
-	return UtilsIsFloat(v) || UtilsIsInt(v)
+	switch v.(type) {
+	case json.Number, decimal.Decimal:
+		return true
+	}
+	return UtilsIsFloat(v) || UtilsIsInt(v) || UtilsIsUint(v)
 }
 
 func UtilsNumberToFloat64(v interface{}) (float64, error) {
-	if UtilsIsNumber(v) {
-		switch v.(type) {
-		case int:
-			vv, _ := v.(int)
-			return float64(vv), nil
-		case int8:
-			vv, _ := v.(int8)
-			return float64(vv), nil
-		case int16:
-			vv, _ := v.(int16)
-			return float64(vv), nil
-		case int32:
-			vv, _ := v.(int32)
-			return float64(vv), nil
-		case int64:
-			vv, _ := v.(int64)
-			return float64(vv), nil
-		case float32:
-			vv, _ := v.(float32)
-			return float64(vv), nil
-		case float64:
-			vv, _ := v.(float64)
-			return vv, nil
-		}
+	switch n := v.(type) {
+	case json.Number:
+		return n.Float64()
+	case decimal.Decimal:
+		f, _ := n.Float64()
+		return f, nil
+	}
+	value := reflect.ValueOf(v)
+	switch {
+	case UtilsIsInt(v):
+		return float64(value.Int()), nil
+	case UtilsIsUint(v):
+		return float64(value.Uint()), nil
+	case UtilsIsFloat(v):
+		return value.Float(), nil
 	}
 	return 0, errors.New("not a number")
 }
 
+// UtilsNumberToDecimal converts a number without the rounding of a float64 where the representation allows it
+func UtilsNumberToDecimal(v interface{}) (decimal.Decimal, error) {
+	switch n := v.(type) {
+	case json.Number:
+		return decimal.NewFromString(n.String())
+	case decimal.Decimal:
+		return n, nil
+	case float32:
+		return decimal.NewFromFloat32(n), nil
+	}
+	value := reflect.ValueOf(v)
+	switch {
+	case UtilsIsInt(v):
+		return decimal.NewFromInt(value.Int()), nil
+	case UtilsIsUint(v):
+		return decimal.NewFromString(strconv.FormatUint(value.Uint(), 10))
+	case UtilsIsFloat(v):
+		return decimal.NewFromFloat(value.Float()), nil
+	}
+	return decimal.Zero, errors.New("not a number")
+}
+
+// UtilsCoerceNumber converts numbers and strings holding a number, like "12.5", to a float64. The second result is
+// false for everything else.
+func UtilsCoerceNumber(v interface{}) (float64, bool) {
+	if str, ok := v.(string); ok {
+		number, ok := UtilsCoerceDecimal(str)
+		f, _ := number.Float64()
+		return f, ok
+	}
+	f, err := UtilsNumberToFloat64(v)
+	return f, err == nil
+}
+
+// UtilsCoerceDecimal converts like UtilsCoerceNumber to a decimal, which keeps the digits of the number
+func UtilsCoerceDecimal(v interface{}) (decimal.Decimal, bool) {
+	if str, ok := v.(string); ok {
+		number, err := decimal.NewFromString(strings.TrimSpace(str))
+		return number, err == nil
+	}
+	number, err := UtilsNumberToDecimal(v)
+	return number, err == nil
+}
+
 func UtilsNumberToFloat64Force(v interface{}) float64 {
 	f, _ := UtilsNumberToFloat64(v)
 	return f
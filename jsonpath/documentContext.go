@@ -11,7 +11,10 @@ type ReadContext interface {
 	JsonString() (string, error)
 	ReadWithFilters(path string, filters ...common.Predicate) (interface{}, error)
 	Read(path string) (interface{}, error)
+	ReadWithVars(path string, vars map[string]interface{}) (interface{}, error)
 	ReadJsonpath(path *Jsonpath) (interface{}, error)
+	ReadPathSet(set *PathSet) (map[string]interface{}, error)
+	Explain(path string) (*Explanation, error)
 	Limit(maxResults int) (ReadContext, error)
 	WithListeners(listeners ...common.EvaluationListener) (ReadContext, error)
 }
@@ -44,15 +47,28 @@ func (jc *JsonContext) JsonString() (string, error) {
 var jsonPathCache = make(map[string]*Jsonpath)
 
 func (jc *JsonContext) pathFromCache(pathString string, filters []common.Predicate) (*Jsonpath, error) {
+	rfc9535Mode := common.UtilsSliceContains(jc.configuration.Options(), common.OPTION_RFC9535)
 	var cacheKey string
 	if filters == nil || len(filters) == 0 {
 		cacheKey = pathString
 	} else {
 		cacheKey = common.UtilsConcat(pathString, common.UtilsToString(filters))
 	}
+	if rfc9535Mode {
+		cacheKey = common.UtilsConcat("rfc9535:", cacheKey)
+	}
 	jp := jsonPathCache[cacheKey]
 	if jp == nil {
-		jsonpath, err := compileJsonpathByStringAndPredicateSlice(pathString, filters)
+		var jsonpath *Jsonpath
+		var err error
+		if rfc9535Mode {
+			if len(filters) > 0 {
+				return nil, &common.InvalidPathError{Message: "Filter predicates are not supported in RFC 9535 mode"}
+			}
+			jsonpath, err = CreateJsonpathRFC9535(pathString)
+		} else {
+			jsonpath, err = compileJsonpathByStringAndPredicateSlice(pathString, filters)
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -78,6 +94,19 @@ func (jc *JsonContext) ReadWithFilters(pathString string, filters ...common.Pred
 	return jc.ReadJsonpath(jp)
 }
 
+// ReadWithVars reads a path with named variables, like $.users[?(@.id == $userId)]. The path is compiled once, the
+// variables are bound to the values of vars for this read only.
+func (jc *JsonContext) ReadWithVars(pathString string, vars map[string]interface{}) (interface{}, error) {
+	if pathString == "" {
+		return nil, errors.New("path can not be empty")
+	}
+	jp, err := jc.pathFromCache(pathString, nil)
+	if err != nil {
+		return nil, err
+	}
+	return jp.readAnyByConfiguration(jc.json, jc.configuration.SetVariables(vars))
+}
+
 func (jc *JsonContext) ReadJsonpath(path *Jsonpath) (interface{}, error) {
 	if path == nil {
 		return nil, errors.New("path can not be nil")
@@ -85,14 +114,32 @@ func (jc *JsonContext) ReadJsonpath(path *Jsonpath) (interface{}, error) {
 	return path.readAnyByConfiguration(jc.json, jc.configuration)
 }
 
+// ReadPathSet reads all paths of the set in one traversal of the document, the results are keyed by path
+func (jc *JsonContext) ReadPathSet(set *PathSet) (map[string]interface{}, error) {
+	if set == nil {
+		return nil, errors.New("path set can not be nil")
+	}
+	return set.Read(jc.json, jc.configuration)
+}
+
+// Explain reads the path and explains why values matched it or not
+func (jc *JsonContext) Explain(pathString string) (*Explanation, error) {
+	if pathString == "" {
+		return nil, errors.New("path can not be empty")
+	}
+	jp, err := jc.pathFromCache(pathString, nil)
+	if err != nil {
+		return nil, err
+	}
+	return jp.Explain(jc.json, jc.configuration), nil
+}
+
 func (jc *JsonContext) Limit(maxResults int) (ReadContext, error) {
 	return jc.WithListeners(createLimitingEvaluationListener(maxResults))
 }
 
 func (jc *JsonContext) WithListeners(listeners ...common.EvaluationListener) (ReadContext, error) {
-	newConfig := common.CreateConfigurationByJsonProviderOptionsMappingProviderEvaluationListeners(
-		jc.configuration.JsonProvider(), jc.configuration.Options(), jc.configuration.MappingProvider(), listeners)
-	return CreateJsonContextByAny(jc.json, newConfig)
+	return CreateJsonContextByAny(jc.json, jc.configuration.SetEvaluationListeners(listeners...))
 }
 
 type LimitingEvaluationListener struct {
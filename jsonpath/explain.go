@@ -0,0 +1,157 @@
+package jsonpath
+
+import (
+	"encoding/json"
+	"github.com/CuiChao512/go-jsonpath/jsonpath/common"
+	"github.com/CuiChao512/go-jsonpath/jsonpath/filter"
+	"strings"
+)
+
+// Explanation records an evaluation of a path: the values the evaluation reached, the tokens applied to them and how
+// the filters tested them. Error is the error the read of the path returns.
+type Explanation struct {
+	Path    string           `json:"path"`
+	Root    *ExplanationNode `json:"root"`
+	Results []string         `json:"results"`
+	Error   string           `json:"error,omitempty"`
+}
+
+// ExplanationNode is a value reached by the evaluation. A property that was read but is missing is a node too.
+type ExplanationNode struct {
+	Path     string               `json:"path"`
+	Missing  bool                 `json:"missing,omitempty"`
+	Result   bool                 `json:"result,omitempty"`
+	Filters  []*FilterExplanation `json:"filters,omitempty"`
+	Tokens   []string             `json:"tokens,omitempty"`
+	Children []*ExplanationNode   `json:"children,omitempty"`
+}
+
+// FilterExplanation is a filter that tested a value, with the explanation of its expression
+type FilterExplanation struct {
+	Filter     string                        `json:"filter"`
+	Result     bool                          `json:"result"`
+	Expression *filter.ExpressionExplanation `json:"expression,omitempty"`
+}
+
+// Explain compiles the path and explains its evaluation on the json document
+func Explain(pathString string, document string) (*Explanation, error) {
+	ctx, err := ParseString(document)
+	if err != nil {
+		return nil, err
+	}
+	return ctx.Explain(pathString)
+}
+
+// Explain evaluates the path on the document like Read does and records why values matched or not
+func (j *Jsonpath) Explain(jsonObject interface{}, config *common.Configuration) *Explanation {
+	tracer := &explainTracer{nodes: map[string]*ExplanationNode{}, results: []string{}}
+	_, err := j.readAnyByConfiguration(jsonObject, config.SetTracer(tracer))
+	explanation := &Explanation{Path: j.path.String(), Root: tracer.root, Results: tracer.results}
+	if err != nil {
+		explanation.Error = err.Error()
+	}
+	return explanation
+}
+
+// explainTracer builds the tree of an explanation from the events of an evaluation
+type explainTracer struct {
+	root    *ExplanationNode
+	nodes   map[string]*ExplanationNode
+	results []string
+}
+
+func (t *explainTracer) Trace(event common.TraceEvent) {
+	node := t.node(event.Path)
+	switch event.Kind {
+	case common.TRACE_TOKEN_ENTERED:
+		node.Tokens = append(node.Tokens, event.Token)
+	case common.TRACE_PROPERTY_READ:
+		node.Missing = event.Value == common.JsonProviderUndefined
+	case common.TRACE_PREDICATE_EVALUATED:
+		explained := &FilterExplanation{Filter: event.Predicate.String(), Result: event.Result}
+		if expression, err := filter.ExplainPredicate(event.Predicate, event.PredicateContext); err == nil {
+			explained.Expression = expression
+		}
+		node.Filters = append(node.Filters, explained)
+	case common.TRACE_RESULT_ADDED:
+		node.Result = true
+		t.results = append(t.results, event.Path)
+	}
+}
+
+// node returns the node of a path, a new node is a child of the node of the longest path that starts its path
+func (t *explainTracer) node(path string) *ExplanationNode {
+	if node, ok := t.nodes[path]; ok {
+		return node
+	}
+	node := &ExplanationNode{Path: path}
+	t.nodes[path] = node
+	if t.root == nil {
+		t.root = node
+		return node
+	}
+	for i := len(path) - 1; i > 0; i-- {
+		if parent, ok := t.nodes[path[:i]]; ok && (path[i-1] == ']' || parent == t.root) {
+			parent.Children = append(parent.Children, node)
+			return node
+		}
+	}
+	t.root.Children = append(t.root.Children, node)
+	return node
+}
+
+// String renders the explanation as an indented tree
+func (e *Explanation) String() string {
+	sb := &strings.Builder{}
+	sb.WriteString(e.Path)
+	sb.WriteString(": ")
+	sb.WriteString(common.UtilsToString(len(e.Results)))
+	sb.WriteString(" results\n")
+	if e.Error != "" {
+		sb.WriteString("error: ")
+		sb.WriteString(e.Error)
+		sb.WriteString("\n")
+	}
+	if e.Root != nil {
+		e.Root.write(sb, "")
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+func (n *ExplanationNode) write(sb *strings.Builder, indent string) {
+	sb.WriteString(indent)
+	sb.WriteString(n.Path)
+	if n.Missing {
+		sb.WriteString(" (missing)")
+	}
+	if n.Result {
+		sb.WriteString(" (result)")
+	}
+	sb.WriteString("\n")
+	for _, f := range n.Filters {
+		sb.WriteString(indent + "  filter " + f.Filter + ": " + common.UtilsToString(f.Result) + "\n")
+		if f.Expression != nil {
+			for _, line := range strings.Split(f.Expression.String(), "\n") {
+				sb.WriteString(indent + "    " + line + "\n")
+			}
+		}
+	}
+	for _, token := range n.Tokens {
+		sb.WriteString(indent + "  token " + token + "\n")
+	}
+	for _, child := range n.Children {
+		child.write(sb, indent+"  ")
+	}
+}
+
+// ToJson renders the explanation as indented json
+func (e *Explanation) ToJson() (string, error) {
+	sb := &strings.Builder{}
+	encoder := json.NewEncoder(sb)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(e); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(sb.String(), "\n"), nil
+}
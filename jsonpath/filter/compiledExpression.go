@@ -0,0 +1,254 @@
+package filter
+
+import (
+	"github.com/CuiChao512/go-jsonpath/jsonpath/common"
+	"github.com/CuiChao512/go-jsonpath/jsonpath/path"
+	"github.com/shopspring/decimal"
+	"strings"
+)
+
+// compiledExpression is an expression tree compiled to closures. The closures decide the operators and the kinds of
+// the constant operands once, when the filter is compiled, instead of for every item. Comparisons of a property of the
+// item with a number or string constant read the property and compare it without creating value nodes.
+type compiledExpression func(ctx common.PredicateContext) (bool, error)
+
+func compileExpression(predicate common.Predicate) compiledExpression {
+	switch e := predicate.(type) {
+	case *LogicalExpressionNode:
+		return compileLogicalExpression(e)
+	case *RelationExpressionNode:
+		return compileRelationExpression(e)
+	}
+	return predicate.Apply
+}
+
+func compileLogicalExpression(e *LogicalExpressionNode) compiledExpression {
+	chain := make([]compiledExpression, len(e.chain))
+	for i, expression := range e.chain {
+		chain[i] = compileExpression(expression)
+	}
+	switch e.operator {
+	case LogicalOperator_OR:
+		return func(ctx common.PredicateContext) (bool, error) {
+			for _, expression := range chain {
+				if result, err := expression(ctx); err != nil || result {
+					return result, err
+				}
+			}
+			return false, nil
+		}
+	case LogicalOperator_AND:
+		return func(ctx common.PredicateContext) (bool, error) {
+			for _, expression := range chain {
+				if result, err := expression(ctx); err != nil || !result {
+					return false, err
+				}
+			}
+			return true, nil
+		}
+	}
+	operand := chain[0]
+	return func(ctx common.PredicateContext) (bool, error) {
+		result, err := operand(ctx)
+		if err != nil {
+			return false, err
+		}
+		return !result, nil
+	}
+}
+
+func compileRelationExpression(e *RelationExpressionNode) compiledExpression {
+	operator := strings.ToUpper(e.relationalOperator)
+	evaluator := CreateEvaluator(operator)
+	if evaluator == nil {
+		return func(ctx common.PredicateContext) (bool, error) {
+			return false, nil
+		}
+	}
+	properties, isProperties := itemProperties(e.left)
+	if isProperties && operator == RelationalOperator_EXISTS {
+		if shouldExist, ok := e.right.(*BooleanNode); ok {
+			return compileExistsCheck(properties, shouldExist.GetBoolean())
+		}
+	}
+	generic := func(left ValueNode, right ValueNode, ctx common.PredicateContext) (bool, error) {
+		left, right = dateOperands(left, right)
+		return evaluator.Evaluate(left, right, ctx)
+	}
+	if isProperties && isComparison(operator) {
+		if compare := compileConstantComparison(operator, e.right); compare != nil {
+			return compilePropertyComparison(e, properties, compare, generic)
+		}
+	}
+	left, right := e.left, e.right
+	return func(ctx common.PredicateContext) (bool, error) {
+		l, err := evaluateValueNode(left, ctx)
+		if err != nil {
+			return false, err
+		}
+		r, err := evaluateValueNode(right, ctx)
+		if err != nil {
+			return false, err
+		}
+		return generic(l, r, ctx)
+	}
+}
+
+// itemProperties returns the properties of a path of single properties read from the item, like @.a.b
+func itemProperties(node ValueNode) ([]string, bool) {
+	pathNode, ok := node.(*PathNode)
+	if !ok || pathNode.variable != "" {
+		return nil, false
+	}
+	compiledPath, ok := pathNode.path.(*path.CompiledPath)
+	if !ok || compiledPath.IsRootPath() || compiledPath.GetRoot().GetPathFragment() != "@" {
+		return nil, false
+	}
+	var properties []string
+	for token := compiledPath.GetRoot().GetNext(); token != nil; token = token.GetNext() {
+		property, ok := token.(*path.PropertyPathToken)
+		if !ok || !property.SinglePropertyCase() {
+			return nil, false
+		}
+		properties = append(properties, property.GetProperties()[0])
+	}
+	return properties, len(properties) > 0
+}
+
+// readProperties reads the properties from the item, ok is false if one of them is missing
+func readProperties(properties []string, ctx common.PredicateContext) (interface{}, bool) {
+	provider := ctx.Configuration().JsonProvider()
+	value := ctx.Item()
+	for _, property := range properties {
+		if !provider.IsMap(value) {
+			return nil, false
+		}
+		value = provider.GetMapValue(value, property)
+		if value == common.JsonProviderUndefined {
+			return nil, false
+		}
+	}
+	return provider.Unwrap(value), true
+}
+
+// compileExistsCheck compiles @.a.b and !@.a.b, an exists check does not depend on the options
+func compileExistsCheck(properties []string, shouldExist bool) compiledExpression {
+	return func(ctx common.PredicateContext) (bool, error) {
+		_, exists := readProperties(properties, ctx)
+		return exists == shouldExist, nil
+	}
+}
+
+func isComparison(operator string) bool {
+	switch operator {
+	case RelationalOperator_EQ, RelationalOperator_NE, RelationalOperator_LT, RelationalOperator_LTE,
+		RelationalOperator_GT, RelationalOperator_GTE:
+		return true
+	}
+	return false
+}
+
+// constantComparison compares a value read from the document with the constant, ok is false if the value is not of
+// the kind of the constant
+type constantComparison func(value interface{}) (result bool, ok bool)
+
+func compileConstantComparison(operator string, constant ValueNode) constantComparison {
+	var sign func(value interface{}) (int, bool)
+	neverEquals := false
+	switch c := constant.(type) {
+	case *NumberNode:
+		number, _ := c.GetNumber().Float64()
+		// a float compares like its decimal only if the constant has a float of the same value
+		if !decimal.NewFromFloat(number).Equal(*c.GetNumber()) {
+			return nil
+		}
+		sign = func(value interface{}) (int, bool) {
+			f, ok := value.(float64)
+			if !ok {
+				return 0, false
+			}
+			if f < number {
+				return -1, true
+			} else if f > number {
+				return 1, true
+			}
+			return 0, true
+		}
+	case *StringNode:
+		str := c.GetString()
+		// a string never equals the empty string, see StringNode.Equals
+		neverEquals = str == "" && (operator == RelationalOperator_EQ || operator == RelationalOperator_NE)
+		sign = func(value interface{}) (int, bool) {
+			s, ok := value.(string)
+			if !ok {
+				return 0, false
+			}
+			return strings.Compare(s, str), true
+		}
+	default:
+		return nil
+	}
+	test := comparisonTest(operator)
+	return func(value interface{}) (bool, bool) {
+		result, ok := sign(value)
+		if !ok {
+			return false, false
+		}
+		if neverEquals {
+			return operator == RelationalOperator_NE, true
+		}
+		return test(result), true
+	}
+}
+
+func comparisonTest(operator string) func(sign int) bool {
+	switch operator {
+	case RelationalOperator_EQ:
+		return func(sign int) bool { return sign == 0 }
+	case RelationalOperator_NE:
+		return func(sign int) bool { return sign != 0 }
+	case RelationalOperator_LT:
+		return func(sign int) bool { return sign < 0 }
+	case RelationalOperator_LTE:
+		return func(sign int) bool { return sign <= 0 }
+	case RelationalOperator_GT:
+		return func(sign int) bool { return sign > 0 }
+	}
+	return func(sign int) bool { return sign >= 0 }
+}
+
+// compilePropertyComparison compiles a comparison like @.price < 10. Options that change what a missing property
+// reads as, and values that are not of the kind of the constant, are left to the value nodes.
+func compilePropertyComparison(e *RelationExpressionNode, properties []string, compare constantComparison, generic func(left ValueNode, right ValueNode, ctx common.PredicateContext) (bool, error)) compiledExpression {
+	return func(ctx common.PredicateContext) (bool, error) {
+		if _, ok := ctx.(*common.PredicateContextImpl); !ok || missingPropertyHasValue(ctx) {
+			l, err := evaluateValueNode(e.left, ctx)
+			if err != nil {
+				return false, err
+			}
+			return generic(l, e.right, ctx)
+		}
+		value, ok := readProperties(properties, ctx)
+		if !ok {
+			return generic(UNDEFINED_NODE, e.right, ctx)
+		}
+		if result, ok := compare(value); ok {
+			return result, nil
+		}
+		l, err := resultValueNode(value, ctx)
+		if err != nil {
+			return false, err
+		}
+		return generic(l, e.right, ctx)
+	}
+}
+
+// missingPropertyHasValue tells if a missing property is read as null instead of undefined
+func missingPropertyHasValue(ctx common.PredicateContext) bool {
+	for _, option := range ctx.Configuration().Options() {
+		if option == common.OPTION_SUPPRESS_EXCEPTIONS || option == common.OPTION_DEFAULT_PATH_LEAF_TO_NULL {
+			return true
+		}
+	}
+	return false
+}
@@ -694,6 +694,15 @@ const (
 	LogicalOperator_OR  = "||"
 )
 
+//ArithmeticOperator
+const (
+	ArithmeticOperator_PLUS     = "+"
+	ArithmeticOperator_MINUS    = "-"
+	ArithmeticOperator_MULTIPLY = "*"
+	ArithmeticOperator_DIVIDE   = "/"
+	ArithmeticOperator_MODULO   = "%"
+)
+
 func CreateEvaluator(operator string) Evaluator {
 	return evaluators[strings.ToUpper(operator)]
 }
@@ -728,9 +737,16 @@ func (e *LogicalExpressionNode) GetOperator() string {
 }
 
 func (e *LogicalExpressionNode) Apply(ctx common.PredicateContext) (bool, error) {
+	return e.apply(func(expression ExpressionNode) (bool, error) {
+		return expression.Apply(ctx)
+	})
+}
+
+// apply combines the results of the operands, && and || stop at the first operand that decides the result
+func (e *LogicalExpressionNode) apply(applyOperand func(expression ExpressionNode) (bool, error)) (bool, error) {
 	if e.operator == LogicalOperator_OR {
 		for _, expression := range e.chain {
-			result, err := expression.Apply(ctx)
+			result, err := applyOperand(expression)
 			if err != nil {
 				return false, err
 			}
@@ -741,7 +757,7 @@ func (e *LogicalExpressionNode) Apply(ctx common.PredicateContext) (bool, error)
 		return false, nil
 	} else if e.operator == LogicalOperator_AND {
 		for _, expression := range e.chain {
-			result, err := expression.Apply(ctx)
+			result, err := applyOperand(expression)
 			if err != nil {
 				return false, err
 			}
@@ -752,7 +768,7 @@ func (e *LogicalExpressionNode) Apply(ctx common.PredicateContext) (bool, error)
 		return true, nil
 	} else {
 		expression := e.chain[0]
-		result, err := expression.Apply(ctx)
+		result, err := applyOperand(expression)
 		if err != nil {
 			return false, err
 		}
@@ -760,6 +776,13 @@ func (e *LogicalExpressionNode) Apply(ctx common.PredicateContext) (bool, error)
 	}
 }
 func (e *LogicalExpressionNode) String() string {
+	if e.operator == LogicalOperator_NOT {
+		operand := e.chain[0].String()
+		if _, isLogical := e.chain[0].(*LogicalExpressionNode); !isLogical {
+			operand = "(" + operand + ")"
+		}
+		return LogicalOperator_NOT + operand
+	}
 	var chainString []string
 	for _, e := range e.chain {
 		chainString = append(chainString, e.String())
@@ -768,9 +791,10 @@ func (e *LogicalExpressionNode) String() string {
 }
 
 func newLogicalExpressionNode(left ExpressionNode, operator string, right ExpressionNode) *LogicalExpressionNode {
-	var chain []ExpressionNode
-	chain[0] = left
-	chain[1] = right
+	chain := []ExpressionNode{left}
+	if right != nil {
+		chain = append(chain, right)
+	}
 	return &LogicalExpressionNode{
 		chain:    chain,
 		operator: operator,
@@ -816,34 +840,41 @@ func (e *RelationExpressionNode) ExpressionNodeLabel() {
 	return
 }
 func (e *RelationExpressionNode) Apply(ctx common.PredicateContext) (bool, error) {
-	l := e.left
-	r := e.right
+	_, _, result, err := e.evaluate(ctx)
+	return result, err
+}
 
-	if e.left.IsPathNode() {
-		pathNode, err := e.left.AsPathNode()
-		if err != nil {
-			return false, err
-		}
-		l, err = pathNode.Evaluate(ctx)
-		if err != nil {
-			return false, err
-		}
+// evaluate returns the operands the expression resolved with its result
+func (e *RelationExpressionNode) evaluate(ctx common.PredicateContext) (ValueNode, ValueNode, bool, error) {
+	l, err := evaluateValueNode(e.left, ctx)
+	if err != nil {
+		return nil, nil, false, err
 	}
-	if e.right.IsPathNode() {
-		pathNode, err := e.right.AsPathNode()
-		if err != nil {
-			return false, err
-		}
-		r, err = pathNode.Evaluate(ctx)
-		if err != nil {
-			return false, err
-		}
+	r, err := evaluateValueNode(e.right, ctx)
+	if err != nil {
+		return l, nil, false, err
 	}
+	l, r = dateOperands(l, r)
 	evaluator := CreateEvaluator(e.relationalOperator)
 	if evaluator != nil {
-		return evaluator.Evaluate(l, r, ctx)
+		result, err := evaluator.Evaluate(l, r, ctx)
+		return l, r, result, err
 	}
-	return false, nil
+	return l, r, false, nil
+}
+
+// dateOperands reads a string compared with a date, like @.expiresAt < now(), as a date
+func dateOperands(left ValueNode, right ValueNode) (ValueNode, ValueNode) {
+	if left.IsOffsetDateTimeNode() && right.IsStringNode() {
+		if date, err := right.AsOffsetDateTimeNode(); err == nil {
+			return left, date
+		}
+	} else if left.IsStringNode() && right.IsOffsetDateTimeNode() {
+		if date, err := left.AsOffsetDateTimeNode(); err == nil {
+			return date, right
+		}
+	}
+	return left, right
 }
 func (e *RelationExpressionNode) String() string {
 	if e.relationalOperator == RelationalOperator_EXISTS {
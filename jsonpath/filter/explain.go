@@ -0,0 +1,153 @@
+package filter
+
+import (
+	"encoding/json"
+	"github.com/CuiChao512/go-jsonpath/jsonpath/common"
+	"strings"
+)
+
+// ExpressionExplanation is how a filter expression evaluated on an item. A relational expression has the operands it
+// resolved, a logical expression has the explanations of the operands it evaluated, && and || stop at the first
+// operand that decides the result.
+type ExpressionExplanation struct {
+	Expression string                   `json:"expression"`
+	Operator   string                   `json:"operator,omitempty"`
+	Left       *Operand                 `json:"left,omitempty"`
+	Right      *Operand                 `json:"right,omitempty"`
+	Operands   []*ExpressionExplanation `json:"operands,omitempty"`
+	Result     bool                     `json:"result"`
+}
+
+// Operand is an operand of a relational expression and the value it resolved to, a path that does not lead to a value
+// is undefined
+type Operand struct {
+	Expression string      `json:"expression"`
+	Value      interface{} `json:"value"`
+	Undefined  bool        `json:"undefined,omitempty"`
+}
+
+func (o *Operand) String() string {
+	if o.Undefined {
+		return "undefined"
+	}
+	if bytes, err := json.Marshal(o.Value); err == nil {
+		return string(bytes)
+	}
+	return common.UtilsToString(o.Value)
+}
+
+// ExplainPredicate applies the predicate to the item of the context and explains the result. The expression tree of a
+// filter is evaluated by the same evaluators that apply it, other predicates are only applied.
+func ExplainPredicate(predicate common.Predicate, ctx common.PredicateContext) (*ExpressionExplanation, error) {
+	switch p := predicate.(type) {
+	case *CompiledFilter:
+		return ExplainPredicate(p.Expression(), ctx)
+	case *LogicalExpressionNode:
+		return explainLogicalExpression(p, ctx)
+	case *RelationExpressionNode:
+		return explainRelationExpression(p, ctx)
+	}
+	result, err := predicate.Apply(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &ExpressionExplanation{Expression: predicate.String(), Result: result}, nil
+}
+
+func explainLogicalExpression(e *LogicalExpressionNode, ctx common.PredicateContext) (*ExpressionExplanation, error) {
+	explanation := &ExpressionExplanation{Expression: e.String(), Operator: e.operator}
+	result, err := e.apply(func(expression ExpressionNode) (bool, error) {
+		operand, err := ExplainPredicate(expression, ctx)
+		if err != nil {
+			return false, err
+		}
+		explanation.Operands = append(explanation.Operands, operand)
+		return operand.Result, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	explanation.Result = result
+	return explanation, nil
+}
+
+func explainRelationExpression(e *RelationExpressionNode, ctx common.PredicateContext) (*ExpressionExplanation, error) {
+	left, right, result, err := e.evaluate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &ExpressionExplanation{
+		Expression: e.String(),
+		Operator:   e.relationalOperator,
+		Left:       createOperand(e.left, left, ctx),
+		Right:      createOperand(e.right, right, ctx),
+		Result:     result,
+	}, nil
+}
+
+func createOperand(expression ValueNode, value ValueNode, ctx common.PredicateContext) *Operand {
+	operand := &Operand{Expression: expression.String()}
+	if value.IsUndefinedNode() {
+		operand.Undefined = true
+	} else {
+		operand.Value = operandValue(value, ctx)
+	}
+	return operand
+}
+
+// operandValue converts a value node to the value it stands for, numbers keep their decimal digits
+func operandValue(node ValueNode, ctx common.PredicateContext) interface{} {
+	switch n := node.(type) {
+	case *NumberNode:
+		return json.Number(n.GetNumber().String())
+	case *StringNode:
+		return n.GetString()
+	case *BooleanNode:
+		return n.GetBoolean()
+	case *NullNode:
+		return nil
+	case *JsonNode:
+		if parsed, err := n.Parse(ctx); err == nil {
+			return parsed
+		}
+		return n.GetJson()
+	case *ValueListNode:
+		values := make([]interface{}, 0, len(n.nodes))
+		for _, value := range n.nodes {
+			values = append(values, operandValue(value, ctx))
+		}
+		return values
+	}
+	return node.String()
+}
+
+// String renders the explanation as indented lines, one for each expression
+func (e *ExpressionExplanation) String() string {
+	sb := &strings.Builder{}
+	e.write(sb, "")
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+func (e *ExpressionExplanation) write(sb *strings.Builder, indent string) {
+	sb.WriteString(indent)
+	if len(e.Operands) > 0 {
+		sb.WriteString(e.Operator)
+	} else {
+		sb.WriteString(e.Expression)
+	}
+	sb.WriteString(": ")
+	sb.WriteString(common.UtilsToString(e.Result))
+	if e.Left != nil {
+		sb.WriteString(" (left ")
+		sb.WriteString(e.Left.String())
+		if e.Operator != RelationalOperator_EXISTS {
+			sb.WriteString(", right ")
+			sb.WriteString(e.Right.String())
+		}
+		sb.WriteString(")")
+	}
+	sb.WriteString("\n")
+	for _, operand := range e.Operands {
+		operand.write(sb, indent+"  ")
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"errors"
 	"fmt"
 	"github.com/CuiChao512/go-jsonpath/jsonpath/common"
-	"log"
 	"reflect"
 	"strconv"
 	"strings"
@@ -32,6 +31,9 @@ const (
 	OR  = '|'
 
 	MINUS       = '-'
+	PLUS        = '+'
+	MULTIPLY    = '*'
+	MODULO      = '%'
 	LT          = '<'
 	GT          = '>'
 	EQ          = '='
@@ -129,7 +131,7 @@ func (c *Compiler) readLogicalANDOperand() (ExpressionNode, error) {
 		}
 	}
 
-	if filter.SkipBlanks().CurrentCharIs(OPEN_PARENTHESIS) {
+	if filter.SkipBlanks().CurrentCharIs(OPEN_PARENTHESIS) && !c.isOperandGroup() {
 		err := filter.ReadSignificantChar(OPEN_PARENTHESIS)
 		if err != nil {
 			return nil, err
@@ -148,12 +150,108 @@ func (c *Compiler) readLogicalANDOperand() (ExpressionNode, error) {
 	return c.readExpression()
 }
 
+// isOperandGroup reports whether the parenthesis at the current position groups an arithmetic operand, like
+// (@.a + @.b) * 2 > 10, instead of logical expressions.
+func (c *Compiler) isOperandGroup() bool {
+	filter := c.filter
+	closingIndex, err := filter.IndexOfMatchingCloseChar(filter.Position(), OPEN_PARENTHESIS, CLOSE_PARENTHESIS, true, false)
+	if err != nil || closingIndex == -1 {
+		return false
+	}
+	nextChar := filter.NextSignificantCharFromStartPosition(closingIndex)
+	return c.isArithmeticOperatorChar(nextChar) || c.isRelationalOperatorChar(nextChar) && nextChar != NOT
+}
+
 func (c *Compiler) readValueNode() (ValueNode, error) {
+	return c.readAdditive()
+}
+
+func (c *Compiler) readAdditive() (ValueNode, error) {
+	left, err := c.readMultiplicative()
+	if err != nil {
+		return left, err
+	}
+	for {
+		operator, ok := c.readArithmeticOperator(ArithmeticOperator_PLUS, ArithmeticOperator_MINUS)
+		if !ok {
+			return left, nil
+		}
+		right, err := c.readMultiplicative()
+		if err != nil {
+			return nil, err
+		}
+		left = CreateArithmeticNode(left, operator, right)
+	}
+}
+
+func (c *Compiler) readMultiplicative() (ValueNode, error) {
+	left, err := c.readUnary()
+	if err != nil {
+		return left, err
+	}
+	for {
+		operator, ok := c.readArithmeticOperator(ArithmeticOperator_MULTIPLY, ArithmeticOperator_DIVIDE, ArithmeticOperator_MODULO)
+		if !ok {
+			return left, nil
+		}
+		right, err := c.readUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = CreateArithmeticNode(left, operator, right)
+	}
+}
+
+func (c *Compiler) readUnary() (ValueNode, error) {
+	filter := c.filter
+	// a minus directly followed by a digit is part of a number literal
+	if filter.SkipBlanks().CurrentCharIs(MINUS) && filter.InBoundsByPosition(filter.Position()+1) &&
+		!filter.IsNumberCharacter(filter.Position()+1) {
+		filter.IncrementPosition(1)
+		operand, err := c.readUnary()
+		if err != nil {
+			return nil, err
+		}
+		return CreateArithmeticNode(nil, ArithmeticOperator_MINUS, operand), nil
+	}
+	if filter.CurrentCharIs(OPEN_PARENTHESIS) {
+		filter.IncrementPosition(1)
+		operand, err := c.readAdditive()
+		if err != nil {
+			return nil, err
+		}
+		if err = filter.ReadSignificantChar(CLOSE_PARENTHESIS); err != nil {
+			return nil, err
+		}
+		return operand, nil
+	}
+	return c.readOperand()
+}
+
+// readArithmeticOperator reads one of the given operators if it is the next significant character.
+func (c *Compiler) readArithmeticOperator(operators ...string) (string, bool) {
+	filter := c.filter
+	savepoint := filter.Position()
+	if filter.SkipBlanks().InBounds() {
+		for _, operator := range operators {
+			if filter.CurrentCharIs([]rune(operator)[0]) {
+				filter.IncrementPosition(1)
+				return operator, true
+			}
+		}
+	}
+	filter.SetPosition(savepoint)
+	return "", false
+}
+
+func (c *Compiler) readOperand() (ValueNode, error) {
 	filter := c.filter
 	currentChar := filter.SkipBlanks().CurrentChar()
-	println("readValueNode currentChar:", string(currentChar))
 	switch currentChar {
 	case DOC_CONTEXT:
+		if filter.VariableNameEnd(filter.Position()+1) > filter.Position()+1 {
+			return c.readVariable()
+		}
 		return c.readPath()
 	case EVAL_CONTEXT:
 		return c.readPath()
@@ -168,13 +266,33 @@ func (c *Compiler) readValueNode() (ValueNode, error) {
 			return nil, &common.InvalidPathError{Message: fmt.Sprintf("Unexpected character: %c", NOT)}
 		}
 	default:
+		if c.filter.IsFunctionCall(c.filter.Position()) {
+			return c.readFunctionCall()
+		}
 		return c.readLiteral()
 	}
 }
 
+// readFunctionCall reads a function that does not follow a path as a function of the document, now() is $.now()
+func (c *Compiler) readFunctionCall() (*PathNode, error) {
+	begin := c.filter.Position()
+	if err := c.skipPath(begin); err != nil {
+		return nil, err
+	}
+	return CreatePathNodeWithString("$."+c.filter.SubSequence(begin, c.filter.Position()), false, false)
+}
+
+// readVariable reads a named variable like $userId, its value is bound when the path is read
+func (c *Compiler) readVariable() (*VariableNode, error) {
+	filter := c.filter
+	begin := filter.Position() + 1
+	end := filter.VariableNameEnd(begin)
+	filter.SetPosition(end)
+	return CreateVariableNode(filter.SubSequence(begin, end)), nil
+}
+
 func (c *Compiler) readLiteral() (ValueNode, error) {
 	currentChar := c.filter.SkipBlanks().CurrentChar()
-	println("readLiteral: currentChar=", string(currentChar))
 	switch currentChar {
 	case SINGLE_QUOTE:
 		return c.readStringLiteral(SINGLE_QUOTE)
@@ -195,7 +313,6 @@ func (c *Compiler) readLiteral() (ValueNode, error) {
 	case PATTERN:
 		return c.readPattern()
 	default:
-		println("readNumberLiteral")
 		return c.readNumberLiteral()
 	}
 }
@@ -218,6 +335,10 @@ func (c *Compiler) readExpression() (*RelationExpressionNode, error) {
 		if err2 == nil || reflect.ValueOf(err2).IsNil() {
 			return CreateRelationExpressionNode(left, operator, right), nil
 		}
+		// an invalid pattern is an error of the expression, not a sign that the left operand stands alone
+		if errors.Is(err2, common.ErrInvalidPattern) {
+			return nil, err2
+		}
 	}
 	filter.SetPosition(savepoint)
 	pathNode, err3 := left.AsPathNode()
@@ -257,12 +378,9 @@ func (c *Compiler) readRelationalOperator() (string, error) {
 func (c *Compiler) readNullLiteral() (*NullNode, error) {
 	filter := c.filter
 
-	begin := filter.Position()
-
 	if filter.CurrentChar() == NULL && filter.InBoundsByPosition(filter.Position()+3) {
 		nullValue := filter.SubSequence(filter.Position(), filter.Position()+4)
 		if "null" == nullValue {
-			log.Printf("NullLiteral from %d to %d -> [%s]", begin, filter.Position()+3, nullValue)
 			filter.IncrementPosition(len(nullValue))
 			return CreateNullNode(), nil
 		}
@@ -341,7 +459,6 @@ func (c *Compiler) readPattern() (*PatternNode, error) {
 		filter.SetPosition(closingIndex + 1)
 	}
 	pattern := filter.SubSequence(begin, filter.Position())
-	log.Printf("PatternNode from %d to %d -> [%s]", begin, filter.Position(), pattern)
 	return CreatePatternNodeByString(pattern)
 }
 
@@ -356,7 +473,6 @@ func (c *Compiler) readStringLiteral(endChar rune) (*StringNode, error) {
 		filter.SetPosition(closingSingleQuoteIndex + 1)
 	}
 	stringLiteral := filter.SubSequence(begin, filter.Position())
-	log.Printf("StringLiteral from %d to %d -> [%s]", begin, filter.Position(), stringLiteral)
 	return CreateStringNode(stringLiteral, true)
 }
 
@@ -365,10 +481,14 @@ func (c *Compiler) readNumberLiteral() (*NumberNode, error) {
 	begin := filter.Position()
 
 	for filter.InBounds() && filter.IsNumberCharacter(filter.Position()) {
+		// a minus inside a number only follows the exponent, 2-1 is a subtraction
+		if filter.CurrentChar() == MINUS && filter.Position() > begin &&
+			filter.CharAt(filter.Position()-1) != common.SCI_e && filter.CharAt(filter.Position()-1) != common.SCI_E {
+			break
+		}
 		filter.IncrementPosition(1)
 	}
 	numberLiteral := filter.SubSequence(begin, filter.Position())
-	log.Printf("NumberLiteral from %d to %d -> [%s]", begin, filter.Position(), numberLiteral)
 	return CreateNumberNodeByString(numberLiteral)
 }
 
@@ -388,7 +508,6 @@ func (c *Compiler) readBooleanLiteral() (*BooleanNode, error) {
 		return nil, &common.InvalidPathError{Message: "Expected boolean literal"}
 	}
 	filter.IncrementPosition(len(boolString))
-	log.Printf("BooleanLiteral from %d to %d -> [%s]", begin, end, boolString)
 	boolValue := false
 	if boolString == "true" {
 		boolValue = true
@@ -400,32 +519,55 @@ func (c *Compiler) readPath() (*PathNode, error) {
 	filter := c.filter
 	previousSignificantChar := filter.PreviousSignificantChar()
 	begin := filter.Position()
+	if err := c.skipPath(begin); err != nil {
+		return nil, err
+	}
+
+	shouldExists := !(previousSignificantChar == NOT)
+	path := filter.SubSequence(begin, filter.Position())
+	return CreatePathNodeWithString(path, false, shouldExists)
+}
+
+func (c *Compiler) skipPath(begin int) error {
+	filter := c.filter
 	filter.IncrementPosition(1)
 
 	for filter.InBounds() {
 		if filter.CurrentChar() == OPEN_SQUARE_BRACKET {
 			closingSquareBracketIndex, err := filter.IndexOfMatchingCloseChar(filter.Position(), OPEN_SQUARE_BRACKET, CLOSE_SQUARE_BRACKET, true, false)
 			if err != nil {
-				return nil, err
+				return err
 			} else if closingSquareBracketIndex == -1 {
-				return nil, &common.InvalidPathError{Message: "Square brackets does not match in filter " + filter.String()}
+				return &common.InvalidPathError{Message: "Square brackets does not match in filter " + filter.String()}
 			} else {
 				filter.SetPosition(closingSquareBracketIndex + 1)
 			}
 		}
+		// skip the parameters of a function, which can contain blanks and operators
+		if filter.InBounds() && filter.CurrentChar() == OPEN_PARENTHESIS {
+			closingParenthesisIndex, err := filter.IndexOfClosingBracket(filter.Position(), true, false)
+			if err != nil {
+				return err
+			} else if closingParenthesisIndex == -1 {
+				return &common.InvalidPathError{Message: "Parentheses do not match in filter " + filter.String()}
+			}
+			filter.SetPosition(closingParenthesisIndex + 1)
+			continue
+		}
+		if !filter.InBounds() {
+			break
+		}
 		closingFunctionBracket := filter.CurrentChar() == CLOSE_PARENTHESIS && c.currentCharIsClosingFunctionBracket(begin)
 		closingLogicalBracket := filter.CurrentChar() == CLOSE_PARENTHESIS && !closingFunctionBracket
 
-		if !filter.InBounds() || c.isRelationalOperatorChar(filter.CurrentChar()) || filter.CurrentChar() == SPACE || closingLogicalBracket {
+		if !filter.InBounds() || c.isRelationalOperatorChar(filter.CurrentChar()) || filter.CurrentChar() == SPACE || closingLogicalBracket ||
+			c.isPathArithmeticOperator(filter.Position()) {
 			break
 		} else {
 			filter.IncrementPosition(1)
 		}
 	}
-
-	shouldExists := !(previousSignificantChar == NOT)
-	path := filter.SubSequence(begin, filter.Position())
-	return CreatePathNodeWithString(path, false, shouldExists)
+	return nil
 }
 
 func (c *Compiler) currentCharIsClosingFunctionBracket(lowerBound int) bool {
@@ -454,6 +596,28 @@ func (*Compiler) isLogicalOperatorChar(c rune) bool {
 	return c == AND || c == OR
 }
 
+func (*Compiler) isArithmeticOperatorChar(c rune) bool {
+	return c == PLUS || c == MINUS || c == MULTIPLY || c == PATTERN || c == MODULO
+}
+
+// isPathArithmeticOperator tells if the character at the position ends a path as an arithmetic operator, like the * of
+// @.price*@.qty. A * after a period is a wildcard and a minus followed by a letter is part of a name like @.first-name,
+// names like item-2 need the bracket notation @['item-2'].
+func (c *Compiler) isPathArithmeticOperator(position int) bool {
+	filter := c.filter
+	switch filter.CharAt(position) {
+	case PLUS, MODULO, PATTERN:
+		return true
+	case MULTIPLY:
+		return filter.CharAtOr(position-1, SPACE) != PERIOD
+	case MINUS:
+		next := filter.CharAtOr(position+1, SPACE)
+		return common.UtilsCharIsDigit(next) || next == SPACE || next == DOC_CONTEXT || next == EVAL_CONTEXT ||
+			next == OPEN_PARENTHESIS || next == MINUS
+	}
+	return false
+}
+
 func (*Compiler) isRelationalOperatorChar(c rune) bool {
 	return c == LT || c == GT || c == EQ || c == TILDE || c == NOT
 }
@@ -511,26 +675,34 @@ func CreateFilterCompiler(filterString string) (*Compiler, error) {
 func Compile(filterString string) (*CompiledFilter, error) {
 	compiler, err := CreateFilterCompiler(filterString)
 	if err != nil {
-		return nil, err
+		return nil, compileError(toSyntaxError(err, common.CreateCharacterIndex(filterString)))
 	}
 	compiledFilter, err := compiler.Compile()
 	if err != nil {
-		return nil, err
+		return nil, compileError(toSyntaxError(err, compiler.filter))
 	}
-	return &CompiledFilter{predicate: compiledFilter}, nil
+	return &CompiledFilter{predicate: compiledFilter, apply: compileExpression(compiledFilter)}, nil
 }
 
+// CompiledFilter is a filter compiled to its expression tree, which it prints, and to closures, which it applies
 type CompiledFilter struct {
 	predicate common.Predicate
+	apply     compiledExpression
 }
 
 func (cf *CompiledFilter) Apply(ctx common.PredicateContext) (bool, error) {
-	return cf.predicate.Apply(ctx)
+	return cf.apply(ctx)
+}
+
+// Expression returns the expression tree of the filter
+func (cf *CompiledFilter) Expression() common.Predicate {
+	return cf.predicate
 }
 
 func (cf *CompiledFilter) String() string {
 	predicateString := cf.predicate.String()
-	if strings.HasPrefix(predicateString, "(") {
+	_, isRelation := cf.predicate.(*RelationExpressionNode)
+	if strings.HasPrefix(predicateString, "(") && !isRelation {
 		return "[?" + predicateString + "]"
 	} else {
 		return "[?(" + predicateString + ")]"
@@ -1,6 +1,7 @@
 package filter
 
 import (
+	"encoding/json"
 	"github.com/CuiChao512/go-jsonpath/jsonpath/common"
 	"github.com/CuiChao512/go-jsonpath/jsonpath/function"
 	pathPkg "github.com/CuiChao512/go-jsonpath/jsonpath/path"
@@ -26,6 +27,8 @@ const (
 	CR                = '\r'
 	LF                = '\n'
 	BEGIN_FILTER      = '?'
+	PATH_PARENT       = '^'
+	PATH_PROPERTY     = '~'
 	COMMA             = ','
 	SPLIT             = ':'
 	PATH_MINUS        = '-'
@@ -60,7 +63,7 @@ func (c *PathCompiler) readContextToken() (*pathPkg.RootPathToken, error) {
 	c.readWhitespace()
 
 	if !c.isPathContext(c.path.CurrentChar()) {
-		return nil, fail("Path must start with '$' or '@'")
+		return nil, c.failAt(c.path.Position(), "Path must start with '$' or '@'", "$", "@")
 	}
 
 	pathToken := pathPkg.CreateRootPathToken(c.path.CurrentChar())
@@ -72,7 +75,7 @@ func (c *PathCompiler) readContextToken() (*pathPkg.RootPathToken, error) {
 	c.path.IncrementPosition(1)
 
 	if c.path.CurrentChar() != PATH_PERIOD && c.path.CurrentChar() != PATH_OPEN_SQUARE_BRACKET {
-		return nil, fail("Illegal character at position " + strconv.FormatInt(int64(c.path.Position()), 10) + " expected '.' or '['")
+		return nil, c.failAt(c.path.Position(), "Illegal character at position "+strconv.FormatInt(int64(c.path.Position()), 10)+" expected '.' or '['", ".", "[")
 	}
 
 	appender := pathToken.GetPathTokenAppender()
@@ -87,43 +90,46 @@ func (c *PathCompiler) readContextToken() (*pathPkg.RootPathToken, error) {
 func (c *PathCompiler) readNextToken(appender pathPkg.TokenAppender) (bool, error) {
 	switch c.path.CurrentChar() {
 	case PATH_OPEN_SQUARE_BRACKET:
-		errMsg := "Could not parse token starting at position " + strconv.Itoa(c.path.Position()) + ". Expected ?, ', 0-9, * "
+		position := c.path.Position()
+		errMsg := "Could not parse token starting at position " + strconv.Itoa(position) + ". Expected ?, ', 0-9, * "
+		expected := []string{"?", "'", "0-9", "*"}
 		readResult, err := c.readBracketPropertyToken(appender)
 		if err != nil {
-			return false, fail(errMsg)
+			return false, c.failAt(position, errMsg, expected...)
 		}
 		if readResult {
 			return true, nil
 		}
 		readResult, err = c.readArrayToken(appender)
 		if err != nil {
-			return false, fail(errMsg)
+			return false, c.failAt(position, errMsg, expected...)
 		}
 		if readResult {
 			return true, nil
 		}
 		readResult, err = c.readWildCardToken(appender)
 		if err != nil {
-			return false, fail(errMsg)
+			return false, c.failAt(position, errMsg, expected...)
 		}
 		if readResult {
 			return true, nil
 		}
 		readResult, err = c.readFilterToken(appender)
 		if err != nil {
-			return false, fail(errMsg)
+			// errors of the filter itself tell best what is wrong
+			return false, err
 		}
 		if readResult {
 			return true, nil
 		}
 		readResult, err = c.readPlaceholderToken(appender)
 		if err != nil {
-			return false, fail(errMsg)
+			return false, c.failAt(position, errMsg, expected...)
 		}
 		if readResult {
 			return true, nil
 		}
-		return false, fail(errMsg)
+		return false, c.failAt(position, errMsg, expected...)
 	case PATH_PERIOD:
 		readResult, err := c.readDotToken(appender)
 		if err != nil {
@@ -132,6 +138,10 @@ func (c *PathCompiler) readNextToken(appender pathPkg.TokenAppender) (bool, erro
 		if !readResult {
 			return false, fail("Could not parse token starting at position " + strconv.Itoa(c.path.Position()))
 		}
+	case PATH_PARENT:
+		return c.readParentToken(appender)
+	case PATH_PROPERTY:
+		return c.readPropertyNameToken(appender)
 	case WILDCARD:
 		readResult, err := c.readWildCardToken(appender)
 		if err != nil {
@@ -169,6 +179,30 @@ func (c *PathCompiler) readDotToken(appender pathPkg.TokenAppender) (bool, error
 	return c.readNextToken(appender)
 }
 
+// isComplete reports whether the whole path has been read. A trailing '^' or '~' is the last character, but still has
+// to be read as a token.
+func (c *PathCompiler) isComplete() bool {
+	return c.path.CurrentIsTail() && !(c.path.InBounds() && (c.path.CurrentCharIs(PATH_PARENT) || c.path.CurrentCharIs(PATH_PROPERTY)))
+}
+
+func (c *PathCompiler) readParentToken(appender pathPkg.TokenAppender) (bool, error) {
+	appender.AppendPathToken(pathPkg.CreateParentPathToken())
+	c.path.IncrementPosition(1)
+	if c.isComplete() {
+		return true, nil
+	}
+	return c.readNextToken(appender)
+}
+
+func (c *PathCompiler) readPropertyNameToken(appender pathPkg.TokenAppender) (bool, error) {
+	appender.AppendPathToken(pathPkg.CreatePropertyNamePathToken())
+	c.path.IncrementPosition(1)
+	if c.isComplete() {
+		return true, nil
+	}
+	return c.readNextToken(appender)
+}
+
 func (c *PathCompiler) readPropertyOrFunctionToken(appender pathPkg.TokenAppender) (bool, error) {
 	path := c.path
 	if path.CurrentCharIs(PATH_OPEN_SQUARE_BRACKET) || path.CurrentCharIs(WILDCARD) || path.CurrentCharIs(PATH_PERIOD) || path.CurrentCharIs(PATH_SPACE) {
@@ -184,7 +218,7 @@ func (c *PathCompiler) readPropertyOrFunctionToken(appender pathPkg.TokenAppende
 		char := path.CharAt(readPosition)
 		if char == PATH_SPACE {
 			return false, &common.InvalidPathError{Message: "Use bracket notion ['my prop'] if your property contains blank characters. position: " + strconv.Itoa(path.Position())}
-		} else if char == PATH_PERIOD || char == PATH_OPEN_SQUARE_BRACKET {
+		} else if char == PATH_PERIOD || char == PATH_OPEN_SQUARE_BRACKET || char == PATH_PARENT || char == PATH_PROPERTY {
 			endPosition = readPosition
 			break
 		} else if char == PATH_OPEN_PARENTHESIS {
@@ -200,19 +234,12 @@ func (c *PathCompiler) readPropertyOrFunctionToken(appender pathPkg.TokenAppende
 
 	var functionParameters []*function.Parameter
 	if isFunction {
-		parenthesisCount := 1
-		for i := readPosition + 1; i < path.Length(); i++ {
-			if path.CharAt(i) == PATH_CLOSE_PARENTHESIS {
-				parenthesisCount--
-			} else if path.CharAt(i) == PATH_OPEN_PARENTHESIS {
-				parenthesisCount++
-			}
-			if parenthesisCount == 0 {
-				break
-			}
+		// parentheses inside of quoted parameters are plain text
+		closingParenthesisIndex, err := path.IndexOfClosingBracket(readPosition, true, false)
+		if err != nil {
+			return false, err
 		}
-
-		if parenthesisCount != 0 {
+		if closingParenthesisIndex == -1 {
 			functionName := path.SubSequence(startPosition, endPosition)
 			return false, &common.InvalidPathError{Message: "Arguments to function: '" + functionName + "' are not closed properly."}
 		}
@@ -230,7 +257,8 @@ func (c *PathCompiler) readPropertyOrFunctionToken(appender pathPkg.TokenAppende
 					return false, err
 				}
 			} else {
-				path.SetPosition(readPosition + 1)
+				// continue after the closing parenthesis, like in trim().lower()
+				path.SetPosition(readPosition + 2)
 			}
 		} else {
 			path.SetPosition(readPosition)
@@ -245,7 +273,7 @@ func (c *PathCompiler) readPropertyOrFunctionToken(appender pathPkg.TokenAppende
 	} else {
 		appender.AppendPathToken(pathPkg.CreatePropertyPathToken([]string{property}, string(PATH_SINGLE_QUOTE)))
 	}
-	if path.CurrentIsTail() {
+	if c.isComplete() {
 		return true, nil
 	}
 	readResult, err := c.readNextToken(appender)
@@ -261,11 +289,12 @@ func (c *PathCompiler) parseFunctionParameters(funcName string) ([]*function.Par
 	// Parenthesis starts at 1 since we're marking the start of a function call, the close paren will denote the
 	// last parameter boundary
 
-	groupParen, groupBracket, groupBrace, groupQuote := 1, 0, 0, 0
+	groupParen, groupBracket, groupBrace, groupQuote, groupSingleQuote := 1, 0, 0, 0, 0
 
 	path := c.path
 	endOfStream := false
 	priorChar := rune(0)
+	escaped := false
 	var parameters []*function.Parameter
 	parameter := ""
 	for path.InBounds() && !endOfStream {
@@ -278,20 +307,45 @@ func (c *PathCompiler) parseFunctionParameters(funcName string) ([]*function.Par
 				continue
 			}
 
-			if char == PATH_OPEN_BRACE || common.UtilsCharIsDigit(char) || PATH_DOUBLE_QUOTE == char {
+			if char == PATH_OPEN_BRACE || common.UtilsCharIsDigit(char) || PATH_DOUBLE_QUOTE == char ||
+				PATH_SINGLE_QUOTE == char || PATH_MINUS == char {
 				paramType = function.JSON
 			} else if c.isPathContext(char) {
 				paramType = function.PATH // read until we reach a terminating comma and we've reset grouping to zero
+			} else if path.IsFunctionCall(path.Position() - 1) {
+				// a function without a path, like now(), is a function of the document
+				paramType = function.PATH
+				parameter = "$."
+			}
+		}
+
+		// grouping characters inside of strings are plain text, up to the unescaped closing quote
+		if groupQuote > 0 || groupSingleQuote > 0 {
+			closingQuote := PATH_SINGLE_QUOTE
+			if groupQuote > 0 {
+				closingQuote = PATH_DOUBLE_QUOTE
+			}
+			if escaped || char != closingQuote {
+				escaped = !escaped && char == '\\'
+				parameter += string(char)
+				priorChar = char
+				continue
 			}
 		}
 
 		switch char {
 		case PATH_DOUBLE_QUOTE:
-			if priorChar != '\\' && groupQuote > 0 {
+			if groupQuote > 0 {
 				groupQuote--
 			} else {
 				groupQuote++
 			}
+		case PATH_SINGLE_QUOTE:
+			if groupSingleQuote > 0 {
+				groupSingleQuote--
+			} else {
+				groupSingleQuote++
+			}
 		case PATH_OPEN_PARENTHESIS:
 			groupParen++
 		case PATH_OPEN_BRACE:
@@ -321,7 +375,7 @@ func (c *PathCompiler) parseFunctionParameters(funcName string) ([]*function.Par
 		case COMMA:
 			// In this state we've reach the end of a function parameter and we can pass along the parameter string
 			// to the parser
-			if 0 == groupQuote && 0 == groupBrace && 0 == groupBracket && ((0 == groupParen && PATH_CLOSE_PARENTHESIS == char) || 1 == groupParen) {
+			if 0 == groupQuote && 0 == groupSingleQuote && 0 == groupBrace && 0 == groupBracket && ((0 == groupParen && PATH_CLOSE_PARENTHESIS == char) || 1 == groupParen) {
 				endOfStream = 0 == groupParen
 
 				if paramType != function.NULL {
@@ -329,7 +383,7 @@ func (c *PathCompiler) parseFunctionParameters(funcName string) ([]*function.Par
 					switch paramType {
 					case function.JSON:
 						// parse the json and set the value
-						param = function.CreateJsonParameter(parameter)
+						param = function.CreateJsonParameter(singleQuotedToJson(parameter))
 					case function.PATH:
 						var predicates []common.Predicate
 						compiler := createPathCompiler(common.CreateCharacterIndex(parameter), &predicates)
@@ -359,6 +413,17 @@ func (c *PathCompiler) parseFunctionParameters(funcName string) ([]*function.Par
 	return parameters, nil
 }
 
+// singleQuotedToJson turns a parameter like 'it\'s' into the JSON string "it's", other parameters are returned as is.
+func singleQuotedToJson(parameter string) string {
+	trimmed := strings.TrimSpace(parameter)
+	if len(trimmed) < 2 || trimmed[0] != PATH_SINGLE_QUOTE || trimmed[len(trimmed)-1] != PATH_SINGLE_QUOTE {
+		return parameter
+	}
+	content := strings.ReplaceAll(trimmed[1:len(trimmed)-1], "\\'", "'")
+	jsonString, _ := json.Marshal(content)
+	return string(jsonString)
+}
+
 func (c *PathCompiler) compile() (common.Path, error) {
 	root, err := c.readContextToken()
 	if err != nil {
@@ -411,7 +476,7 @@ func (c *PathCompiler) readPlaceholderToken(appender pathPkg.TokenAppender) (boo
 	appender.AppendPathToken(pathPkg.CreatePredicatePathToken(predicates))
 
 	path.SetPosition(expressionEndIndex + 1)
-	if path.CurrentIsTail() {
+	if c.isComplete() {
 		return true, nil
 	}
 
@@ -453,12 +518,12 @@ func (c *PathCompiler) readFilterToken(appender pathPkg.TokenAppender) (bool, er
 
 	predicate0, e := Compile(criteria)
 	if e != nil {
-		return false, nil
+		return false, shiftSyntaxError(e, path.String(), openStatementBracketIndex)
 	}
 	appender.AppendPathToken(pathPkg.CreatePredicatePathToken([]common.Predicate{predicate0}))
 
 	path.SetPosition(closeStatementBracketIndex + 1)
-	if path.CurrentIsTail() {
+	if c.isComplete() {
 		return true, nil
 	}
 	readResult, e := c.readNextToken(appender)
@@ -491,7 +556,7 @@ func (c *PathCompiler) readWildCardToken(appender pathPkg.TokenAppender) (bool,
 	}
 
 	appender.AppendPathToken(pathPkg.CreateWildcardPathToken())
-	if path.CurrentIsTail() {
+	if c.isComplete() {
 		return true, nil
 	}
 	readResult, e := c.readNextToken(appender)
@@ -549,7 +614,7 @@ func (c *PathCompiler) readArrayToken(appender pathPkg.TokenAppender) (bool, err
 	}
 
 	path.SetPosition(expressionEndIndex + 1)
-	if path.CurrentIsTail() {
+	if c.isComplete() {
 		return true, nil
 	}
 	readResult, e := c.readNextToken(appender)
@@ -629,7 +694,7 @@ func (c *PathCompiler) readBracketPropertyToken(appender pathPkg.TokenAppender)
 
 	appender.AppendPathToken(pathPkg.CreatePropertyPathToken(properties, string(potentialStringDelimiter)))
 
-	if path.CurrentIsTail() {
+	if c.isComplete() {
 		return true, nil
 	}
 	readResult, e := c.readNextToken(appender)
@@ -644,6 +709,63 @@ func fail(message string) *common.InvalidPathError {
 	return &common.InvalidPathError{Message: message}
 }
 
+func (c *PathCompiler) failAt(position int, message string, expected ...string) *common.PathSyntaxError {
+	return common.CreatePathSyntaxError(message, c.path.String(), position, expected...)
+}
+
+// syntaxErrorOf returns the PathSyntaxError of an error found while compiling, the compile functions return it as the
+// cause of an InvalidPathError
+func syntaxErrorOf(err error) (*common.PathSyntaxError, bool) {
+	switch e := err.(type) {
+	case *common.PathSyntaxError:
+		return e, true
+	case *common.InvalidPathError:
+		if syntaxError, ok := e.Cause.(*common.PathSyntaxError); ok && e.ErrorCode == common.CodePathSyntax {
+			return syntaxError, true
+		}
+	}
+	return nil, false
+}
+
+// toSyntaxError gives an error found while compiling the position it is found at, which is the current position of the
+// compiler unless the error already tells it.
+func toSyntaxError(err error, ci *common.CharacterIndex) error {
+	if e, ok := syntaxErrorOf(err); ok {
+		if e.Path == ci.String() {
+			return e
+		}
+		syntaxError := common.CreatePathSyntaxError(e.Message, ci.String(), ci.Position(), e.Expected...)
+		syntaxError.Cause = e.Cause
+		return syntaxError
+	}
+	if e, ok := err.(*common.InvalidPathError); ok {
+		syntaxError := common.CreatePathSyntaxError(e.Message, ci.String(), ci.Position())
+		if e.ErrorCode != "" || e.Cause != nil {
+			syntaxError.Cause = e
+		}
+		return syntaxError
+	}
+	return err
+}
+
+// shiftSyntaxError moves an error into the path that contains the compiled one at the given shift
+func shiftSyntaxError(err error, path string, shift int) error {
+	if e, ok := syntaxErrorOf(err); ok {
+		syntaxError := common.CreatePathSyntaxError(e.Message, path, e.Position()+shift, e.Expected...)
+		syntaxError.Cause = e.Cause
+		return syntaxError
+	}
+	return err
+}
+
+// compileError is the error a compile function returns for an error found while compiling
+func compileError(err error) error {
+	if e, ok := err.(*common.PathSyntaxError); ok {
+		return common.CreateInvalidPathErrorBySyntaxError(e)
+	}
+	return err
+}
+
 func createPathCompiler(path *common.CharacterIndex, filterStack *[]common.Predicate) *PathCompiler {
 	return &PathCompiler{path: path, filterStack: *filterStack}
 }
@@ -655,13 +777,16 @@ func PathCompile(pathString string, filters ...common.Predicate) (common.Path, e
 func PathCompileByStringAndPredicateSlice(pathString string, filters []common.Predicate) (common.Path, error) {
 	ci := common.CreateCharacterIndex(pathString)
 
+	prefix := 0
 	if ci.CharAt(0) != PATH_DOC_CONTEXT && ci.CharAt(0) != PATH_EVAL_CONTEXT {
 		ci = common.CreateCharacterIndex("$." + pathString)
+		prefix = 2
 	}
 	ci.Trim()
 
 	if ci.LastCharIs('.') {
-		return nil, fail("Path must not end with a '.' or '..'")
+		return nil, common.CreateInvalidPathErrorBySyntaxError(
+			common.CreatePathSyntaxError("Path must not end with a '.' or '..'", pathString, len([]rune(pathString))-1))
 	}
 
 	var filterStack []common.Predicate
@@ -671,5 +796,9 @@ func PathCompileByStringAndPredicateSlice(pathString string, filters []common.Pr
 		filterStack = filters[:]
 	}
 
-	return createPathCompiler(ci, &filterStack).compile()
+	compiledPath, err := createPathCompiler(ci, &filterStack).compile()
+	if err != nil {
+		return nil, compileError(shiftSyntaxError(toSyntaxError(err, ci), pathString, -prefix))
+	}
+	return compiledPath, nil
 }
@@ -5,9 +5,11 @@ import (
 	"errors"
 	"fmt"
 	"github.com/CuiChao512/go-jsonpath/jsonpath/common"
+	pathPkg "github.com/CuiChao512/go-jsonpath/jsonpath/path"
 	"reflect"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/shopspring/decimal"
 )
@@ -53,7 +55,7 @@ func CreatePatternNodeByString(pattern string) (*PatternNode, error) {
 	}
 	compiledPattern, err := regexp.Compile(patternString)
 	if err != nil {
-		return nil, err
+		return nil, &common.InvalidPathError{Message: "Invalid pattern " + pattern + ": " + err.Error(), ErrorDetails: common.ErrorDetails{ErrorCode: common.CodeInvalidPattern, Cause: err}}
 	}
 	return &PatternNode{pattern: purePattern, flags: flags, compiledPattern: compiledPattern}, nil
 }
@@ -129,14 +131,38 @@ type PathNode struct {
 	path        common.Path
 	existsCheck bool
 	shouldExist bool
+	// variable is the name following '@' when the path starts at the surroundings of the item, like @parent
+	variable string
+}
+
+// filterVariables can follow '@' to refer to the surroundings of the filtered item instead of the item itself. A filter
+// applied to an object tests the object itself, its members are only tested one by one, and named by @property, with
+// OPTION_FILTER_OBJECT_MEMBERS.
+var filterVariables = []string{"parentProperty", "parent", "property", "path"}
+
+func splitFilterVariable(pathString string) (string, string) {
+	if !strings.HasPrefix(pathString, "@") {
+		return "", pathString
+	}
+	for _, variable := range filterVariables {
+		if !strings.HasPrefix(pathString[1:], variable) {
+			continue
+		}
+		rest := pathString[1+len(variable):]
+		if rest == "" || rest[0] == '.' || rest[0] == '[' {
+			return variable, "@" + rest
+		}
+	}
+	return "", pathString
 }
 
 func CreatePathNodeWithString(pathString string, existsCheck bool, shouldExist bool) (*PathNode, error) {
+	variable, pathString := splitFilterVariable(pathString)
 	compiledPath, err := PathCompile(pathString)
 	if err != nil {
 		return nil, err
 	}
-	return &PathNode{path: compiledPath, existsCheck: existsCheck, shouldExist: shouldExist}, nil
+	return &PathNode{path: compiledPath, existsCheck: existsCheck, shouldExist: shouldExist, variable: variable}, nil
 }
 
 func CreatePathNode(path common.Path, existsCheck bool, shouldExist bool) *PathNode {
@@ -168,27 +194,70 @@ func (pn *PathNode) AsExistsCheck(shouldExist bool) *PathNode {
 		path:        pn.path,
 		existsCheck: true,
 		shouldExist: shouldExist,
+		variable:    pn.variable,
 	}
 }
 
 func (pn *PathNode) String() string {
+	pathString := pn.path.String()
+	if pn.variable != "" {
+		pathString = "@" + pn.variable + pathString[1:]
+	}
 	if pn.existsCheck && !pn.shouldExist {
-		return "!" + pn.path.String()
+		return "!" + pathString
 	} else {
-		return pn.path.String()
+		return pathString
 	}
 }
 
+// variableContext moves the predicate context to the value of the variable the path starts at. It returns false if
+// the variable has no value for the current item.
+func (pn *PathNode) variableContext(ctx common.PredicateContext) (common.PredicateContext, bool) {
+	ctxi, ok := ctx.(*common.PredicateContextImpl)
+	if !ok || ctxi.Location() == nil {
+		return nil, false
+	}
+	var value interface{}
+	switch pn.variable {
+	case "parent":
+		value = ctxi.Location().Parent()
+	case "parentProperty":
+		value = ctxi.Location().ParentProperty()
+	case "property":
+		value = ctxi.Location().Property()
+	case "path":
+		value = ctxi.Location().Path()
+	}
+	if value == common.JsonProviderUndefined {
+		return nil, false
+	}
+	return common.CreatePredicateContextImpl(value, ctx.Root(), ctx.Configuration(), map[common.Path]interface{}{}), true
+}
+
 func (pn *PathNode) GetPath() common.Path {
 	return pn.path
 }
 
 func (pn *PathNode) Evaluate(ctx common.PredicateContext) (ValueNode, error) {
+	if pn.variable != "" {
+		variableCtx, ok := pn.variableContext(ctx)
+		if !ok {
+			if pn.IsExistsCheck() {
+				return FALSE_NODE, nil
+			}
+			return UNDEFINED_NODE, nil
+		}
+		ctx = variableCtx
+	}
 	if pn.IsExistsCheck() {
-		c := common.CreateConfiguration(ctx.Configuration().JsonProvider(), []common.Option{common.OPTION_ALWAYS_RETURN_LIST}, ctx.Configuration().MappingProvider())
+		c := ctx.Configuration().SetOptions(common.OPTION_ALWAYS_RETURN_LIST).SetEvaluationListeners()
 		evaluationCtx, err := pn.path.Evaluate(ctx.Item(), ctx.Root(), c)
 		if err == nil {
 			if result, err := evaluationCtx.GetValueUnwrap(false); err == nil {
+				// a function returning a boolean, like @.name.startsWith('J'), is a condition
+				if b, ok := result.(bool); ok && pn.path.IsFunctionPath() {
+					return CreateBooleanNode(b), nil
+				}
 				if result == common.JsonProviderUndefined {
 					return FALSE_NODE, nil
 				} else {
@@ -223,37 +292,44 @@ func (pn *PathNode) Evaluate(ctx common.PredicateContext) (ValueNode, error) {
 			}
 		}
 
-		res = ctx.Configuration().JsonProvider().Unwrap(res)
-		resString := common.UtilsToString(res)
-
-		switch res.(type) {
-		case int:
-			return CreateNumberNodeByString(resString)
-		case float32:
-			return CreateNumberNodeByString(resString)
-		case float64:
-			return CreateNumberNodeByString(resString)
-		case string:
-			return CreateStringNode(resString, false)
-		case bool:
-			resBool := false
-			if resString == "true" {
-				resBool = true
-			}
-			return CreateBooleanNode(resBool), nil
-		case *OffsetDateTimeNode:
-			return CreateOffsetDateTimeNode(resString), nil
+		if date, ok := res.(string); ok && pathPkg.IsDatePath(pn.path) {
+			return CreateOffsetDateTimeNode(date), nil
 		}
+		return resultValueNode(res, ctx)
+	}
+}
 
-		if res == nil {
-			return NULL_NODE, nil
-		} else if ctx.Configuration().JsonProvider().IsArray(res) {
-			return CreateJsonNodeByObject(ctx.Configuration().MappingProvider().MapSlice(res, ctx.Configuration())), nil
-		} else if ctx.Configuration().JsonProvider().IsMap(res) {
-			return CreateJsonNodeByObject(ctx.Configuration().MappingProvider().MapMap(res, ctx.Configuration())), nil
-		} else {
-			return nil, &common.JsonPathError{Message: fmt.Sprintf("Could not convert %t: %s to a ValueNode", res, resString)}
+// resultValueNode converts the value a path reads from the document to a node
+func resultValueNode(res interface{}, ctx common.PredicateContext) (ValueNode, error) {
+	res = ctx.Configuration().JsonProvider().Unwrap(res)
+	resString := common.UtilsToString(res)
+
+	if common.UtilsIsNumber(res) {
+		return numberNodeOf(res)
+	}
+	switch res.(type) {
+	case string:
+		return CreateStringNode(resString, false)
+	case bool:
+		resBool := false
+		if resString == "true" {
+			resBool = true
 		}
+		return CreateBooleanNode(resBool), nil
+	case *OffsetDateTimeNode:
+		return CreateOffsetDateTimeNode(resString), nil
+	case time.Time:
+		return CreateOffsetDateTimeNodeByTime(res.(time.Time)), nil
+	}
+
+	if res == nil {
+		return NULL_NODE, nil
+	} else if ctx.Configuration().JsonProvider().IsArray(res) {
+		return CreateJsonNodeByObject(ctx.Configuration().MappingProvider().MapSlice(res, ctx.Configuration())), nil
+	} else if ctx.Configuration().JsonProvider().IsMap(res) {
+		return CreateJsonNodeByObject(ctx.Configuration().MappingProvider().MapMap(res, ctx.Configuration())), nil
+	} else {
+		return nil, &common.JsonPathError{Message: fmt.Sprintf("Could not convert %t: %s to a ValueNode", res, resString)}
 	}
 }
 
@@ -261,6 +337,213 @@ func (pn *PathNode) Equals(o interface{}) bool {
 	return false
 }
 
+// ArithmeticNode -----------
+
+// ArithmeticNode computes a number from two operands, or negates a single one when left is nil. Operands that are not
+// numbers, and division by zero, give an undefined result.
+type ArithmeticNode struct {
+	*defaultPatternNode
+	*defaultPathNode
+	*defaultNumberNode
+	*defaultStringNode
+	*defaultBooleanNode
+	*defaultPredicateNode
+	*defaultValueListNode
+	*defaultNullNode
+	*defaultUndefinedNode
+	*defaultClassNode
+	*defaultOffsetDateTimeNode
+	*defaultJsonNode
+	left     ValueNode
+	operator string
+	right    ValueNode
+}
+
+func (n *ArithmeticNode) TypeOf(ctx common.PredicateContext) reflect.Kind {
+	return reflect.Invalid
+}
+
+func arithmeticPrecedence(node ValueNode) int {
+	an, ok := node.(*ArithmeticNode)
+	switch {
+	case !ok:
+		return 3
+	case an.left == nil:
+		return 2
+	case an.operator == ArithmeticOperator_PLUS || an.operator == ArithmeticOperator_MINUS:
+		return 0
+	default:
+		return 1
+	}
+}
+
+func (n *ArithmeticNode) String() string {
+	precedence := arithmeticPrecedence(n)
+	right := n.right.String()
+	if arithmeticPrecedence(n.right) < precedence || n.left != nil && arithmeticPrecedence(n.right) == precedence {
+		right = "(" + right + ")"
+	}
+	if n.left == nil {
+		return n.operator + right
+	}
+	left := n.left.String()
+	if arithmeticPrecedence(n.left) < precedence {
+		left = "(" + left + ")"
+	}
+	return left + " " + n.operator + " " + right
+}
+
+func (n *ArithmeticNode) Equals(o interface{}) bool {
+	return false
+}
+
+func arithmeticOperand(node ValueNode, ctx common.PredicateContext) (*decimal.Decimal, error) {
+	value, err := evaluateValueNode(node, ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !value.IsNumberNode() && !value.IsStringNode() {
+		return nil, nil
+	}
+	number, err := value.AsNumberNode()
+	if err != nil {
+		return nil, nil
+	}
+	return number.GetNumber(), nil
+}
+
+func (n *ArithmeticNode) Evaluate(ctx common.PredicateContext) (ValueNode, error) {
+	right, err := arithmeticOperand(n.right, ctx)
+	if err != nil || right == nil {
+		return UNDEFINED_NODE, err
+	}
+	if n.left == nil {
+		result := right.Neg()
+		return CreateNumberNode(&result), nil
+	}
+	left, err := arithmeticOperand(n.left, ctx)
+	if err != nil || left == nil {
+		return UNDEFINED_NODE, err
+	}
+	var result decimal.Decimal
+	switch n.operator {
+	case ArithmeticOperator_PLUS:
+		result = left.Add(*right)
+	case ArithmeticOperator_MINUS:
+		result = left.Sub(*right)
+	case ArithmeticOperator_MULTIPLY:
+		result = left.Mul(*right)
+	case ArithmeticOperator_DIVIDE:
+		if right.IsZero() {
+			return UNDEFINED_NODE, nil
+		}
+		result = left.Div(*right)
+	case ArithmeticOperator_MODULO:
+		if right.IsZero() {
+			return UNDEFINED_NODE, nil
+		}
+		result = left.Mod(*right)
+	}
+	return CreateNumberNode(&result), nil
+}
+
+func CreateArithmeticNode(left ValueNode, operator string, right ValueNode) *ArithmeticNode {
+	return &ArithmeticNode{left: left, operator: operator, right: right}
+}
+
+// VariableNode -----------
+
+// VariableNode is a named variable like $userId. Its value is bound by the configuration the path is read with, so
+// values never become part of the path text.
+type VariableNode struct {
+	*defaultPatternNode
+	*defaultPathNode
+	*defaultNumberNode
+	*defaultStringNode
+	*defaultBooleanNode
+	*defaultPredicateNode
+	*defaultValueListNode
+	*defaultNullNode
+	*defaultUndefinedNode
+	*defaultClassNode
+	*defaultOffsetDateTimeNode
+	*defaultJsonNode
+	name string
+}
+
+func (n *VariableNode) TypeOf(ctx common.PredicateContext) reflect.Kind {
+	return reflect.Invalid
+}
+
+func (n *VariableNode) GetName() string {
+	return n.name
+}
+
+func (n *VariableNode) String() string {
+	return "$" + n.name
+}
+
+func (n *VariableNode) Equals(o interface{}) bool {
+	that, ok := o.(*VariableNode)
+	return ok && that.name == n.name
+}
+
+func (n *VariableNode) Evaluate(ctx common.PredicateContext) (ValueNode, error) {
+	value, ok := ctx.Configuration().Variable(n.name)
+	if !ok {
+		return nil, &common.InvalidPathError{Message: "Variable $" + n.name + " is not bound"}
+	}
+	return boundValueNode(value)
+}
+
+// boundValueNode creates the node of a bound value. Strings stay strings, they are not read as paths or json like
+// CreateValueNode does.
+func boundValueNode(value interface{}) (ValueNode, error) {
+	if str, ok := value.(string); ok {
+		return CreateStringNode(str, false)
+	}
+	if value == nil {
+		return NULL_NODE, nil
+	}
+	switch reflect.TypeOf(value).Kind() {
+	case reflect.Slice:
+		items, err := common.ConvertToAnySlice(value)
+		if err != nil {
+			return nil, err
+		}
+		nodes := make([]ValueNode, 0, len(items))
+		for _, item := range items {
+			node, err := boundValueNode(item)
+			if err != nil {
+				return nil, err
+			}
+			nodes = append(nodes, node)
+		}
+		return &ValueListNode{nodes: nodes}, nil
+	case reflect.Map:
+		return CreateJsonNodeByObject(value), nil
+	}
+	return CreateValueNode(value)
+}
+
+func CreateVariableNode(name string) *VariableNode {
+	return &VariableNode{name: name}
+}
+
+// evaluateValueNode resolves the nodes that depend on the item under test to the values they stand for.
+func evaluateValueNode(node ValueNode, ctx common.PredicateContext) (ValueNode, error) {
+	switch n := node.(type) {
+	case *PathNode:
+		return n.Evaluate(ctx)
+	case *ArithmeticNode:
+		return n.Evaluate(ctx)
+	case *VariableNode:
+		return n.Evaluate(ctx)
+	default:
+		return node, nil
+	}
+}
+
 // NumberNode -----------
 type NumberNode struct {
 	*defaultPatternNode
@@ -346,6 +629,15 @@ func CreateNumberNodeByString(str string) (*NumberNode, error) {
 
 }
 
+// numberNodeOf creates the node of any numeric representation, see common.UtilsIsNumber
+func numberNodeOf(number interface{}) (*NumberNode, error) {
+	d, err := common.UtilsNumberToDecimal(number)
+	if err != nil {
+		return nil, err
+	}
+	return CreateNumberNode(&d), nil
+}
+
 // StringNode -----------
 type StringNode struct {
 	*defaultPatternNode
@@ -375,6 +667,15 @@ func (n *StringNode) AsNumberNode() (*NumberNode, error) {
 	}
 }
 
+// AsOffsetDateTimeNode reads an RFC 3339 date, so that strings compare with the dates of functions like now()
+func (n *StringNode) AsOffsetDateTimeNode() (*OffsetDateTimeNode, error) {
+	t, err := time.Parse(time.RFC3339Nano, n.str)
+	if err != nil {
+		return nil, &common.InvalidPathError{Message: "Expected offset date time node"}
+	}
+	return CreateOffsetDateTimeNodeByTime(t), nil
+}
+
 func (n *StringNode) GetString() string {
 	return n.str
 }
@@ -749,10 +1050,18 @@ type ClassNode struct {
 
 // OffsetDateTime -----
 type OffsetDateTime struct {
+	time time.Time
+}
+
+func (o *OffsetDateTime) Time() time.Time {
+	return o.time
 }
 
 func (o *OffsetDateTime) String() string {
-	return ""
+	if o == nil {
+		return ""
+	}
+	return o.time.Format(time.RFC3339Nano)
 }
 
 // OffsetDateTimeNode -----------
@@ -809,21 +1118,41 @@ func (n *OffsetDateTimeNode) Equals(o interface{}) bool {
 		return OffsetDateTimeCompare(n.dateTime, that.dateTime) == 0
 	case *StringNode:
 		v, _ := o.(ValueNode)
-		that, _ := v.AsOffsetDateTimeNode()
-		return OffsetDateTimeCompare(n.dateTime, that.dateTime) == 0
+		that, err := v.AsOffsetDateTimeNode()
+		return err == nil && OffsetDateTimeCompare(n.dateTime, that.dateTime) == 0
 	default:
 		return false
 	}
 }
 
+// OffsetDateTimeCompare orders dates by the instant they stand for, a date that could not be parsed comes first
 func OffsetDateTimeCompare(this *OffsetDateTime, that *OffsetDateTime) int {
-	//TODO:
-	return 0
+	switch {
+	case this == nil && that == nil:
+		return 0
+	case this == nil:
+		return -1
+	case that == nil:
+		return 1
+	case this.time.Before(that.time):
+		return -1
+	case this.time.After(that.time):
+		return 1
+	default:
+		return 0
+	}
 }
 
 func CreateOffsetDateTimeNode(str string) *OffsetDateTimeNode {
-	//TODO
-	return &OffsetDateTimeNode{dateTime: nil}
+	t, err := time.Parse(time.RFC3339Nano, str)
+	if err != nil {
+		return &OffsetDateTimeNode{dateTime: nil}
+	}
+	return CreateOffsetDateTimeNodeByTime(t)
+}
+
+func CreateOffsetDateTimeNodeByTime(t time.Time) *OffsetDateTimeNode {
+	return &OffsetDateTimeNode{dateTime: &OffsetDateTime{time: t}}
 }
 
 // JsonNode --------
@@ -1065,10 +1394,6 @@ func CreateValueNode(o interface{}) (ValueNode, error) {
 		return CreateStringNode(common.UtilsToString(o), false)
 	case rune:
 		return CreateStringNode(common.UtilsToString(o), true)
-	case int:
-		return CreateNumberNodeByString(common.UtilsToString(o))
-	case float64:
-		return CreateNumberNodeByString(common.UtilsToString(o))
 	case bool:
 		return CreateBooleanNodeByString(common.UtilsToString(o)), nil
 	case *regexp.Regexp:
@@ -1076,6 +1401,11 @@ func CreateValueNode(o interface{}) (ValueNode, error) {
 		return CreatePatternNodeByRegexp(r), nil
 	case OffsetDateTime:
 		return CreateOffsetDateTimeNode(common.UtilsToString(o)), nil
+	case time.Time:
+		return CreateOffsetDateTimeNodeByTime(o.(time.Time)), nil
+	}
+	if common.UtilsIsNumber(o) {
+		return numberNodeOf(o)
 	}
 	return nil, &common.JsonPathError{Message: "Could not determine value type"}
 }
@@ -12,10 +12,23 @@ type LateBindingValue struct {
 	path          common.Path
 	rootDocument  string
 	configuration *common.Configuration
+	document      interface{}
 	result        interface{}
+	evaluated     bool
 }
 
+// Get evaluates the path the first time the value is needed, functions can also use the path itself, like sort(@.key).
 func (l *LateBindingValue) Get() (interface{}, error) {
+	if !l.evaluated {
+		e, err := l.path.Evaluate(l.document, l.document, l.configuration)
+		if err != nil {
+			return nil, err
+		}
+		if l.result, err = e.GetValue(); err != nil {
+			return nil, err
+		}
+		l.evaluated = true
+	}
 	return l.result, nil
 }
 
@@ -28,12 +41,11 @@ func (l *LateBindingValue) Equals(o interface{}) bool {
 		return false
 	}
 
-	if common.UtilsGetPtrElem(l) != common.UtilsGetPtrElem(o) {
+	that, ok := o.(*LateBindingValue)
+	if !ok {
 		return false
 	}
 
-	that, _ := o.(*LateBindingValue)
-
 	return l.path == that.path && l.rootDocument == that.rootDocument && l.configuration == that.configuration
 }
 
@@ -42,15 +54,7 @@ func CreateLateBindingValue(path common.Path, rootDocument interface{}, configur
 	l.path = path
 	l.rootDocument = common.UtilsToString(rootDocument)
 	l.configuration = configuration
-	e, err := path.Evaluate(rootDocument, rootDocument, configuration)
-	if err != nil {
-		return nil, err
-	}
-	l.result, err = e.GetValue()
-	if err != nil {
-		return nil, err
-	}
-
+	l.document = rootDocument
 	return l, nil
 }
 
@@ -4,10 +4,18 @@ import (
 	"errors"
 	"github.com/CuiChao512/go-jsonpath/jsonpath/common"
 	"github.com/CuiChao512/go-jsonpath/jsonpath/filter"
+	"github.com/CuiChao512/go-jsonpath/jsonpath/path"
+	"github.com/CuiChao512/go-jsonpath/jsonpath/rfc9535"
 )
 
 type Jsonpath struct {
 	path common.Path
+	// definite reads definite paths of properties and indexes without evaluating them, it is nil for other paths
+	definite *path.DefinitePath
+}
+
+func createJsonpath(p common.Path) *Jsonpath {
+	return &Jsonpath{path: p, definite: path.CreateDefinitePath(p)}
 }
 
 func (j *Jsonpath) GetPath() string {
@@ -19,24 +27,76 @@ func (j *Jsonpath) find(path string, data interface{}, configs *common.Configura
 }
 
 func (j *Jsonpath) readAnyByConfiguration(jsonObject interface{}, config *common.Configuration) (interface{}, error) {
+	result, err := j.readAny(jsonObject, config)
+	if err != nil {
+		return nil, common.ErrorWithPath(err, j.path.String())
+	}
+	return result, nil
+}
+
+func (j *Jsonpath) readAny(jsonObject interface{}, config *common.Configuration) (interface{}, error) {
+	if result, ok := j.readDefinite(jsonObject, config); ok {
+		return result, nil
+	}
+	if result, skip := j.skipEvaluation(config); skip {
+		return result, nil
+	}
+	evaluationContext, err := j.path.Evaluate(jsonObject, jsonObject, config)
+	if err != nil {
+		return nil, err
+	}
+	return j.readResult(evaluationContext, config)
+}
+
+// readDefinite reads a definite path with direct lookups. It returns false when the path does not lead to a value, the
+// path is evaluated then.
+func (j *Jsonpath) readDefinite(jsonObject interface{}, config *common.Configuration) (interface{}, bool) {
+	if j.definite == nil || len(config.GetEvaluationListeners()) > 0 || config.Tracer() != nil {
+		return nil, false
+	}
+	value, ok := j.definite.Read(jsonObject, config.JsonProvider())
+	if !ok {
+		return nil, false
+	}
+	if config.ContainsOption(common.OPTION_AS_PATH_LIST) {
+		value = j.definite.PathString()
+	} else if value != nil {
+		value = config.JsonProvider().Unwrap(value)
+	}
+	if config.ContainsOption(common.OPTION_AS_PATH_LIST) || config.ContainsOption(common.OPTION_ALWAYS_RETURN_LIST) {
+		array := config.JsonProvider().CreateArray()
+		if err := config.JsonProvider().SetArrayIndex(&array, 0, value); err != nil {
+			return nil, false
+		}
+		return array, true
+	}
+	return value, true
+}
+
+// skipEvaluation returns the result of a function path that is read as a list with suppressed exceptions, which is
+// returned without evaluating the path
+func (j *Jsonpath) skipEvaluation(config *common.Configuration) (interface{}, bool) {
+	optAsPathList := common.UtilsSliceContains(config.Options(), common.OPTION_AS_PATH_LIST)
+	optAlwaysReturnList := common.UtilsSliceContains(config.Options(), common.OPTION_ALWAYS_RETURN_LIST)
+	optSuppressException := common.UtilsSliceContains(config.Options(), common.OPTION_SUPPRESS_EXCEPTIONS)
+	if j.path.IsFunctionPath() && (optAsPathList || optAlwaysReturnList) && optSuppressException {
+		if j.path.IsDefinite() {
+			return nil, true
+		} else {
+			return config.JsonProvider().CreateArray(), true
+		}
+	}
+	return nil, false
+}
+
+// readResult returns the result of an evaluated path, as it is shaped by the options
+func (j *Jsonpath) readResult(evaluationContext common.EvaluationContext, config *common.Configuration) (interface{}, error) {
 	optAsPathList := common.UtilsSliceContains(config.Options(), common.OPTION_AS_PATH_LIST)
 	optAlwaysReturnList := common.UtilsSliceContains(config.Options(), common.OPTION_ALWAYS_RETURN_LIST)
 	optSuppressException := common.UtilsSliceContains(config.Options(), common.OPTION_SUPPRESS_EXCEPTIONS)
+	optRFC9535 := common.UtilsSliceContains(config.Options(), common.OPTION_RFC9535)
 
 	if j.path.IsFunctionPath() {
-		if optAsPathList || optAlwaysReturnList {
-			if optSuppressException {
-				if j.path.IsDefinite() {
-					return nil, nil
-				} else {
-					return config.JsonProvider().CreateArray(), nil
-				}
-			}
-		}
-		evaluationContext, err := j.path.Evaluate(jsonObject, jsonObject, config)
-		if err != nil {
-			return nil, err
-		}
 		pathList, err := evaluationContext.GetPathList()
 		if err != nil {
 			return nil, err
@@ -46,23 +106,15 @@ func (j *Jsonpath) readAnyByConfiguration(jsonObject interface{}, config *common
 		}
 		return evaluationContext.GetValueUnwrap(true)
 	} else if optAsPathList {
-		evaluationContext, err := j.path.Evaluate(jsonObject, jsonObject, config)
-		if err != nil {
-			return nil, err
-		}
 		pathList, err := evaluationContext.GetPathList()
 		if err != nil {
 			return nil, err
 		}
-		if optSuppressException && len(pathList) == 0 {
+		if (optSuppressException || optRFC9535) && len(pathList) == 0 {
 			return config.JsonProvider().CreateArray(), nil
 		}
 		return evaluationContext.GetPath()
 	} else {
-		evaluationContext, err := j.path.Evaluate(jsonObject, jsonObject, config)
-		if err != nil {
-			return nil, err
-		}
 		if optSuppressException {
 			pathList, err := evaluationContext.GetPathList()
 			if err != nil {
@@ -86,7 +138,7 @@ func (j *Jsonpath) readAnyByConfiguration(jsonObject interface{}, config *common
 		}
 		if optAlwaysReturnList && j.path.IsDefinite() {
 			array := config.JsonProvider().CreateArray()
-			err = config.JsonProvider().SetArrayIndex(array, 0, res)
+			err = config.JsonProvider().SetArrayIndex(&array, 0, res)
 			if err != nil {
 				return nil, err
 			}
@@ -105,7 +157,16 @@ func CreateJsonpathByStringAndPredicates(jsonpath string, filters []common.Predi
 	if err != nil {
 		return nil, err
 	}
-	return &Jsonpath{path: p}, nil
+	return createJsonpath(p), nil
+}
+
+// CreateJsonpathRFC9535 compiles a query with the RFC 9535 grammar.
+func CreateJsonpathRFC9535(query string) (*Jsonpath, error) {
+	p, err := rfc9535.Compile(query)
+	if err != nil {
+		return nil, err
+	}
+	return createJsonpath(p), nil
 }
 
 func compileJsonpathByStringAndPredicateSlice(jsonpath string, filters []common.Predicate) (*Jsonpath, error) {
@@ -20,6 +20,10 @@ func (pCtx *ParseContextImpl) ParseString(json string) (DocumentContext, error)
 	}
 	obj, err := pCtx.configuration.JsonProvider().Parse(json)
 	if err != nil {
+		var invalidJson *common.InvalidJsonError
+		if !errors.As(err, &invalidJson) {
+			err = &common.InvalidJsonError{Message: "Failed to parse json: " + err.Error(), ErrorDetails: common.ErrorDetails{Cause: err}}
+		}
 		return nil, err
 	}
 	return CreateJsonContextByAny(obj, pCtx.configuration)
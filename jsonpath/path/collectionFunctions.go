@@ -0,0 +1,265 @@
+package path
+
+import (
+	"github.com/CuiChao512/go-jsonpath/jsonpath/common"
+	"github.com/CuiChao512/go-jsonpath/jsonpath/function"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+func arrayModel(name string, model interface{}, ctx common.EvaluationContext) ([]interface{}, error) {
+	if !ctx.Configuration().JsonProvider().IsArray(model) {
+		return nil, &common.JsonPathError{Message: "Function " + name + "() expects an array, got: " + common.UtilsToString(model)}
+	}
+	return ctx.Configuration().JsonProvider().ToArray(model)
+}
+
+// First function
+type First struct {
+	*valueFunction
+}
+
+func (*First) Invoke(nextAndGet PathFunctionNextAndGet, currentPath string, parent common.PathRef, model interface{}, ctx common.EvaluationContext, parameters []*function.Parameter) (interface{}, error) {
+	return arrayItem("first", model, ctx, 0)
+}
+
+// Last function
+type Last struct {
+	*valueFunction
+}
+
+func (*Last) Invoke(nextAndGet PathFunctionNextAndGet, currentPath string, parent common.PathRef, model interface{}, ctx common.EvaluationContext, parameters []*function.Parameter) (interface{}, error) {
+	return arrayItem("last", model, ctx, -1)
+}
+
+// Index function, index(n) is the item at position n, a negative position counts from the end of the array.
+type Index struct {
+	*valueFunction
+}
+
+func (*Index) Invoke(nextAndGet PathFunctionNextAndGet, currentPath string, parent common.PathRef, model interface{}, ctx common.EvaluationContext, parameters []*function.Parameter) (interface{}, error) {
+	index, err := intParameter("index", parameters, 0)
+	if err != nil {
+		return nil, err
+	}
+	return arrayItem("index", model, ctx, index)
+}
+
+func arrayItem(name string, model interface{}, ctx common.EvaluationContext, index int) (interface{}, error) {
+	items, err := arrayModel(name, model, ctx)
+	if err != nil {
+		return nil, err
+	}
+	if index < 0 {
+		index += len(items)
+	}
+	if index < 0 || index >= len(items) {
+		return nil, &common.JsonPathError{Message: "Function " + name + "() found no item at index " + common.UtilsToString(index) + " of an array of length " + common.UtilsToString(len(items))}
+	}
+	return items[index], nil
+}
+
+// Distinct function, keeps the first occurrence of every item.
+type Distinct struct {
+	*valueFunction
+}
+
+func (*Distinct) Invoke(nextAndGet PathFunctionNextAndGet, currentPath string, parent common.PathRef, model interface{}, ctx common.EvaluationContext, parameters []*function.Parameter) (interface{}, error) {
+	items, err := arrayModel("distinct", model, ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := ctx.Configuration().JsonProvider().CreateArray()
+	seen := map[interface{}]bool{}
+	for _, item := range items {
+		if item != nil && !reflect.TypeOf(item).Comparable() {
+			if !containsDeepEqual(result, item) {
+				result = append(result, item)
+			}
+		} else if !seen[item] {
+			seen[item] = true
+			result = append(result, item)
+		}
+	}
+	return result, nil
+}
+
+func containsDeepEqual(items []interface{}, item interface{}) bool {
+	for _, candidate := range items {
+		if reflect.DeepEqual(candidate, item) {
+			return true
+		}
+	}
+	return false
+}
+
+// Reverse function
+type Reverse struct {
+	*valueFunction
+}
+
+func (*Reverse) Invoke(nextAndGet PathFunctionNextAndGet, currentPath string, parent common.PathRef, model interface{}, ctx common.EvaluationContext, parameters []*function.Parameter) (interface{}, error) {
+	items, err := arrayModel("reverse", model, ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := ctx.Configuration().JsonProvider().CreateArray()
+	for i := len(items) - 1; i >= 0; i-- {
+		result = append(result, items[i])
+	}
+	return result, nil
+}
+
+// Sort function, sort(), sort('desc'), sort(@.key) or sort(@.key, 'desc'). Items are ordered null, booleans, numbers,
+// strings and then other values, items without the key come last.
+type Sort struct {
+	*valueFunction
+}
+
+func (*Sort) Invoke(nextAndGet PathFunctionNextAndGet, currentPath string, parent common.PathRef, model interface{}, ctx common.EvaluationContext, parameters []*function.Parameter) (interface{}, error) {
+	items, err := arrayModel("sort", model, ctx)
+	if err != nil {
+		return nil, err
+	}
+	var key common.Path
+	descending := false
+	for i, parameter := range parameters {
+		if parameter.GetType() == function.PATH {
+			key = parameter.GetPath()
+			continue
+		}
+		order, err := stringParameter("sort", parameters, i)
+		if err != nil {
+			return nil, err
+		}
+		switch strings.ToLower(order) {
+		case "asc":
+		case "desc":
+			descending = true
+		default:
+			return nil, &common.InvalidPathError{Message: "Function sort() expects 'asc' or 'desc', got: " + order}
+		}
+	}
+	keys := make([]interface{}, len(items))
+	for i, item := range items {
+		keys[i] = item
+		if key != nil {
+			keys[i] = sortKey(key, item, ctx)
+		}
+	}
+	indexes := make([]int, len(items))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.SliceStable(indexes, func(i, j int) bool {
+		left, right := keys[indexes[i]], keys[indexes[j]]
+		// missing keys stay last in both orders
+		if left == common.JsonProviderUndefined || right == common.JsonProviderUndefined {
+			return right == common.JsonProviderUndefined && left != common.JsonProviderUndefined
+		}
+		if descending {
+			return compareSortValues(right, left) < 0
+		}
+		return compareSortValues(left, right) < 0
+	})
+	result := ctx.Configuration().JsonProvider().CreateArray()
+	for _, index := range indexes {
+		result = append(result, items[index])
+	}
+	return result, nil
+}
+
+func sortKey(key common.Path, item interface{}, ctx common.EvaluationContext) interface{} {
+	keyCtx, err := key.Evaluate(item, ctx.RootDocument(), ctx.Configuration())
+	if err != nil {
+		return common.JsonProviderUndefined
+	}
+	value, err := keyCtx.GetValue()
+	if err != nil {
+		return common.JsonProviderUndefined
+	}
+	return value
+}
+
+func sortRank(value interface{}) int {
+	switch {
+	case value == nil:
+		return 0
+	case reflect.TypeOf(value).Kind() == reflect.Bool:
+		return 1
+	case common.UtilsIsNumber(value):
+		return 2
+	case reflect.TypeOf(value).Kind() == reflect.String:
+		return 3
+	default:
+		return 4
+	}
+}
+
+func compareSortValues(left interface{}, right interface{}) int {
+	leftRank, rightRank := sortRank(left), sortRank(right)
+	if leftRank != rightRank {
+		return leftRank - rightRank
+	}
+	switch leftRank {
+	case 1:
+		leftBool, _ := left.(bool)
+		rightBool, _ := right.(bool)
+		if leftBool == rightBool {
+			return 0
+		} else if rightBool {
+			return -1
+		}
+		return 1
+	case 2:
+		leftNumber, _ := common.UtilsNumberToFloat64(left)
+		rightNumber, _ := common.UtilsNumberToFloat64(right)
+		if leftNumber < rightNumber {
+			return -1
+		} else if leftNumber > rightNumber {
+			return 1
+		}
+		return 0
+	case 3:
+		return strings.Compare(common.UtilsToString(left), common.UtilsToString(right))
+	default:
+		return 0
+	}
+}
+
+// Flatten function, flatten() merges nested arrays into their parent array, flatten(depth) does so depth levels deep.
+type Flatten struct {
+	*valueFunction
+}
+
+func (*Flatten) Invoke(nextAndGet PathFunctionNextAndGet, currentPath string, parent common.PathRef, model interface{}, ctx common.EvaluationContext, parameters []*function.Parameter) (interface{}, error) {
+	items, err := arrayModel("flatten", model, ctx)
+	if err != nil {
+		return nil, err
+	}
+	depth := 1
+	if len(parameters) > 0 {
+		if depth, err = intParameter("flatten", parameters, 0); err != nil {
+			return nil, err
+		}
+	}
+	return flattenItems(ctx.Configuration().JsonProvider().CreateArray(), items, depth, ctx)
+}
+
+func flattenItems(result []interface{}, items []interface{}, depth int, ctx common.EvaluationContext) ([]interface{}, error) {
+	for _, item := range items {
+		if depth > 0 && ctx.Configuration().JsonProvider().IsArray(item) {
+			nested, err := ctx.Configuration().JsonProvider().ToArray(item)
+			if err != nil {
+				return nil, err
+			}
+			if result, err = flattenItems(result, nested, depth-1, ctx); err != nil {
+				return nil, err
+			}
+		} else {
+			result = append(result, item)
+		}
+	}
+	return result, nil
+}
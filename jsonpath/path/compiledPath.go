@@ -2,7 +2,6 @@ package path
 
 import (
 	"github.com/CuiChao512/go-jsonpath/jsonpath/common"
-	"github.com/CuiChao512/go-jsonpath/jsonpath/function"
 )
 
 type CompiledPath struct {
@@ -48,54 +47,43 @@ func (cp *CompiledPath) GetRoot() *RootPathToken {
 	return cp.root
 }
 
+// invertScannerFunctionRelationship turns $..path.function() into a function applied to all results of $..path at
+// once, instead of to each of them. Collecting functions, like distinct(), are applied to the results of any indefinite
+// path this way.
 func invertScannerFunctionRelationship(path *RootPathToken) (*RootPathToken, error) {
-	if path.IsFunctionPath() {
-		next, err := path.nextToken()
-		if err != nil {
-			return nil, err
+	if !path.IsFunctionPath() || path.isLeaf() {
+		return path, nil
+	}
+	_, scan := path.GetNext().(*ScanPathToken)
+	var prior Token = path
+	token := path.GetNext()
+	definite := true
+	for token != nil {
+		if _, ok := token.(*FunctionPathToken); ok {
+			break
 		}
-		switch next.(type) {
-		case *ScanPathToken:
-			var token Token = path
-			var prior Token = nil
-
-			for true {
-				token, err = token.nextToken()
-				if token != nil {
-					switch token.(type) {
-					case *FunctionPathToken:
-						continue
-					}
-				}
-				break
-			}
-			// Invert the relationship $..path.function() to $.function($..path)
-			switch token.(type) {
-			case *FunctionPathToken:
-				prior.SetNext(nil)
-				path.SetTail(prior)
-
-				// Now generate a new parameter from our path
-				parameter := &function.Parameter{}
-				compiledPath, err := CreateCompiledPath(path, true)
-				if err != nil {
-					return nil, err
-				}
-				parameter.SetPath(compiledPath)
-				parameter.SetType(function.PATH)
-				functionToken, _ := token.(*FunctionPathToken)
-				functionToken.SetParameters([]*function.Parameter{parameter})
-				functionRoot := CreateRootPathToken('$')
-				functionRoot.SetTail(functionToken)
-				functionRoot.SetNext(functionToken)
-
-				// Define the function as the root
-				return functionRoot, nil
+		definite = definite && token.IsTokenDefinite()
+		prior = token
+		token = token.GetNext()
+	}
+	functionToken, ok := token.(*FunctionPathToken)
+	if !ok || prior == Token(path) || !scan && (definite || !isCollectingFunction(functionToken.functionName)) {
+		return path, nil
+	}
+	tail := path.GetTail()
+	prior.SetNext(nil)
+	path.SetTail(prior)
 
-			}
-		}
+	source, err := CreateCompiledPath(path, true)
+	if err != nil {
+		return nil, err
 	}
-	return path, nil
+	functionToken.source = source
+	functionRoot := CreateRootPathToken([]rune(path.rootToken)[0])
+	functionRoot.SetNext(functionToken)
+	functionRoot.SetTail(tail)
+	functionToken.SetPrev(functionRoot)
+	return functionRoot, nil
 }
 
 func CreateCompiledPath(rootPathToken *RootPathToken, isRootPath bool) (*CompiledPath, error) {
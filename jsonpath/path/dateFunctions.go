@@ -0,0 +1,220 @@
+package path
+
+import (
+	"github.com/CuiChao512/go-jsonpath/jsonpath/common"
+	"github.com/CuiChao512/go-jsonpath/jsonpath/function"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// dateOf reads a date: a time.Time, an RFC 3339 string or a number of milliseconds since the epoch
+func dateOf(name string, value interface{}) (time.Time, error) {
+	switch date := value.(type) {
+	case time.Time:
+		return date, nil
+	case string:
+		if parsed, err := time.Parse(time.RFC3339Nano, date); err == nil {
+			return parsed, nil
+		}
+	default:
+		if millis, err := common.UtilsNumberToFloat64(value); err == nil {
+			return time.UnixMilli(int64(millis)).UTC(), nil
+		}
+	}
+	return time.Time{}, &common.JsonPathError{Message: "Function " + name + "() expects a date, got: " + common.UtilsToString(value)}
+}
+
+// dateResult writes a date as an RFC 3339 string, the results of the date functions are json values
+func dateResult(date time.Time) string {
+	return date.Format(time.RFC3339Nano)
+}
+
+// dateFunction is a function whose result is a date
+type dateFunction interface {
+	returnsDate()
+}
+
+// IsDatePath tells if a path ends with a function whose result is a date, like now() or @.expiresAt.dateAdd('7d'). Filters
+// compare the results of such paths as dates.
+func IsDatePath(p common.Path) bool {
+	compiledPath, ok := p.(*CompiledPath)
+	if !ok {
+		return false
+	}
+	tail, ok := compiledPath.root.GetTail().(*FunctionPathToken)
+	if !ok {
+		return false
+	}
+	pathFunction, err := GetFunctionByName(tail.functionName)
+	_, isDate := pathFunction.(dateFunction)
+	return err == nil && isDate
+}
+
+var dateUnits = map[string]time.Duration{
+	"ms":      time.Millisecond,
+	"seconds": time.Second,
+	"minutes": time.Minute,
+	"hours":   time.Hour,
+	"days":    24 * time.Hour,
+}
+
+// Now function, the time of the clock of the configuration
+type Now struct {
+	*valueFunction
+}
+
+func (*Now) returnsDate() {}
+
+func (*Now) Invoke(nextAndGet PathFunctionNextAndGet, currentPath string, parent common.PathRef, model interface{}, ctx common.EvaluationContext, parameters []*function.Parameter) (interface{}, error) {
+	return dateResult(ctx.Configuration().Clock().Now()), nil
+}
+
+// ParseDate function, parseDate(layout) reads a string with a Go time layout like '2006-01-02'
+type ParseDate struct {
+	*valueFunction
+}
+
+func (*ParseDate) returnsDate() {}
+
+func (*ParseDate) Invoke(nextAndGet PathFunctionNextAndGet, currentPath string, parent common.PathRef, model interface{}, ctx common.EvaluationContext, parameters []*function.Parameter) (interface{}, error) {
+	str, err := stringModel("parseDate", model)
+	if err != nil {
+		return nil, err
+	}
+	layout, err := stringParameter("parseDate", parameters, 0)
+	if err != nil {
+		return nil, err
+	}
+	date, err := time.Parse(layout, str)
+	if err != nil {
+		return nil, &common.JsonPathError{Message: "Function parseDate() can not parse: " + str}
+	}
+	return dateResult(date), nil
+}
+
+// FormatDate function, formatDate(layout) writes a date with a Go time layout, RFC 3339 without a layout
+type FormatDate struct {
+	*valueFunction
+}
+
+func (*FormatDate) Invoke(nextAndGet PathFunctionNextAndGet, currentPath string, parent common.PathRef, model interface{}, ctx common.EvaluationContext, parameters []*function.Parameter) (interface{}, error) {
+	date, err := dateOf("formatDate", model)
+	if err != nil {
+		return nil, err
+	}
+	layout := time.RFC3339
+	if len(parameters) > 0 {
+		if layout, err = stringParameter("formatDate", parameters, 0); err != nil {
+			return nil, err
+		}
+	}
+	return date.Format(layout), nil
+}
+
+// EpochMillis function, the milliseconds since the epoch
+type EpochMillis struct {
+	*valueFunction
+}
+
+func (*EpochMillis) Invoke(nextAndGet PathFunctionNextAndGet, currentPath string, parent common.PathRef, model interface{}, ctx common.EvaluationContext, parameters []*function.Parameter) (interface{}, error) {
+	date, err := dateOf("epochMillis", model)
+	if err != nil {
+		return nil, err
+	}
+	return date.UnixMilli(), nil
+}
+
+// DateAdd function, dateAdd('24h') adds a Go duration like '-90m', or a number of days like '7d'
+type DateAdd struct {
+	*valueFunction
+}
+
+func (*DateAdd) returnsDate() {}
+
+func (*DateAdd) Invoke(nextAndGet PathFunctionNextAndGet, currentPath string, parent common.PathRef, model interface{}, ctx common.EvaluationContext, parameters []*function.Parameter) (interface{}, error) {
+	date, err := dateOf("dateAdd", model)
+	if err != nil {
+		return nil, err
+	}
+	amount, err := stringParameter("dateAdd", parameters, 0)
+	if err != nil {
+		return nil, err
+	}
+	if strings.HasSuffix(amount, "d") {
+		if days, err := strconv.Atoi(strings.TrimSuffix(amount, "d")); err == nil {
+			return dateResult(date.AddDate(0, 0, days)), nil
+		}
+	} else if duration, err := time.ParseDuration(amount); err == nil {
+		return dateResult(date.Add(duration)), nil
+	}
+	return nil, &common.InvalidPathError{Message: "Function dateAdd() expects a duration like '24h' or '7d', got: " + amount}
+}
+
+// DateDiff function, dateDiff(other, 'days') is the time from another date to a date in ms, seconds, minutes, hours
+// or days
+type DateDiff struct {
+	*valueFunction
+}
+
+func (*DateDiff) Invoke(nextAndGet PathFunctionNextAndGet, currentPath string, parent common.PathRef, model interface{}, ctx common.EvaluationContext, parameters []*function.Parameter) (interface{}, error) {
+	date, err := dateOf("dateDiff", model)
+	if err != nil {
+		return nil, err
+	}
+	value, err := parameterValue("dateDiff", parameters, 0)
+	if err != nil {
+		return nil, err
+	}
+	other, err := dateOf("dateDiff", value)
+	if err != nil {
+		return nil, err
+	}
+	unit := "ms"
+	if len(parameters) > 1 {
+		if unit, err = stringParameter("dateDiff", parameters, 1); err != nil {
+			return nil, err
+		}
+	}
+	duration, ok := dateUnits[unit]
+	if !ok {
+		return nil, &common.InvalidPathError{Message: "Function dateDiff() does not know the unit: " + unit}
+	}
+	return float64(date.Sub(other)) / float64(duration), nil
+}
+
+// Truncate function, truncate('day') sets the parts of a date smaller than a second, minute, hour, day, month or year
+// to their start
+type Truncate struct {
+	*valueFunction
+}
+
+func (*Truncate) returnsDate() {}
+
+func (*Truncate) Invoke(nextAndGet PathFunctionNextAndGet, currentPath string, parent common.PathRef, model interface{}, ctx common.EvaluationContext, parameters []*function.Parameter) (interface{}, error) {
+	date, err := dateOf("truncate", model)
+	if err != nil {
+		return nil, err
+	}
+	unit, err := stringParameter("truncate", parameters, 0)
+	if err != nil {
+		return nil, err
+	}
+	year, month, day := date.Date()
+	hour, minute, second := date.Clock()
+	switch unit {
+	case "second":
+		return dateResult(time.Date(year, month, day, hour, minute, second, 0, date.Location())), nil
+	case "minute":
+		return dateResult(time.Date(year, month, day, hour, minute, 0, 0, date.Location())), nil
+	case "hour":
+		return dateResult(time.Date(year, month, day, hour, 0, 0, 0, date.Location())), nil
+	case "day":
+		return dateResult(time.Date(year, month, day, 0, 0, 0, 0, date.Location())), nil
+	case "month":
+		return dateResult(time.Date(year, month, 1, 0, 0, 0, 0, date.Location())), nil
+	case "year":
+		return dateResult(time.Date(year, time.January, 1, 0, 0, 0, 0, date.Location())), nil
+	}
+	return nil, &common.InvalidPathError{Message: "Function truncate() does not know the unit: " + unit}
+}
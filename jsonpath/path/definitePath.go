@@ -0,0 +1,101 @@
+package path
+
+import (
+	"github.com/CuiChao512/go-jsonpath/jsonpath/common"
+	"strconv"
+	"strings"
+)
+
+// DefinitePath is a definite path of single properties and single indexes, like $.a.b[3].c. It reads its value with
+// direct lookups, without an evaluation context, path strings or path refs.
+type DefinitePath struct {
+	root       string
+	steps      []definiteStep
+	pathString string
+}
+
+type definiteStep struct {
+	property string
+	index    int
+	isIndex  bool
+}
+
+// CreateDefinitePath returns nil if the path has other tokens than single properties and single indexes
+func CreateDefinitePath(p common.Path) *DefinitePath {
+	compiledPath, ok := p.(*CompiledPath)
+	if !ok {
+		return nil
+	}
+	definite := &DefinitePath{root: compiledPath.root.rootToken}
+	for token := compiledPath.root.GetNext(); token != nil; token = token.GetNext() {
+		switch t := token.(type) {
+		case *PropertyPathToken:
+			if !t.SinglePropertyCase() {
+				return nil
+			}
+			definite.steps = append(definite.steps, definiteStep{property: t.properties[0]})
+		case *ArrayIndexPathToken:
+			if !t.arrayIndexOperation.IsSingleIndexOperation() {
+				return nil
+			}
+			definite.steps = append(definite.steps, definiteStep{index: t.arrayIndexOperation.Indexes()[0], isIndex: true})
+		default:
+			return nil
+		}
+	}
+	definite.pathString = definite.buildPathString()
+	return definite
+}
+
+// Read returns the value the path leads to. It returns false when a property or index is missing, or the document does
+// not have the shape of the path, the path is evaluated then to get the error or empty result of the options.
+func (d *DefinitePath) Read(document interface{}, provider common.JsonProvider) (interface{}, bool) {
+	model := document
+	for _, step := range d.steps {
+		if !step.isIndex {
+			if !provider.IsMap(model) {
+				return nil, false
+			}
+			model = provider.GetMapValue(model, step.property)
+			if model == common.JsonProviderUndefined {
+				return nil, false
+			}
+			continue
+		}
+		if !provider.IsArray(model) {
+			return nil, false
+		}
+		index := step.index
+		if index < 0 {
+			length, err := provider.Length(model)
+			if err != nil || length+index < 0 {
+				return nil, false
+			}
+			index += length
+		}
+		value, err := provider.GetArrayIndex(model, index)
+		if err != nil {
+			return nil, false
+		}
+		model = value
+	}
+	return model, true
+}
+
+// PathString returns the path of the value, as the evaluation of the path reports it
+func (d *DefinitePath) PathString() string {
+	return d.pathString
+}
+
+func (d *DefinitePath) buildPathString() string {
+	sb := &strings.Builder{}
+	sb.WriteString(d.root)
+	for _, step := range d.steps {
+		if step.isIndex {
+			sb.WriteString("[" + strconv.Itoa(step.index) + "]")
+		} else {
+			sb.WriteString("['" + step.property + "']")
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,106 @@
+package path
+
+import (
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"github.com/CuiChao512/go-jsonpath/jsonpath/common"
+	"github.com/CuiChao512/go-jsonpath/jsonpath/function"
+	"net/url"
+)
+
+// Sha256 function, the hex encoded SHA-256 hash of a string or of the JSON text of other values
+type Sha256 struct {
+	*valueFunction
+}
+
+func (*Sha256) Invoke(nextAndGet PathFunctionNextAndGet, currentPath string, parent common.PathRef, model interface{}, ctx common.EvaluationContext, parameters []*function.Parameter) (interface{}, error) {
+	text, err := textOf(model, ctx)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Md5 function, the hex encoded MD5 hash of a string or of the JSON text of other values
+type Md5 struct {
+	*valueFunction
+}
+
+func (*Md5) Invoke(nextAndGet PathFunctionNextAndGet, currentPath string, parent common.PathRef, model interface{}, ctx common.EvaluationContext, parameters []*function.Parameter) (interface{}, error) {
+	text, err := textOf(model, ctx)
+	if err != nil {
+		return nil, err
+	}
+	sum := md5.Sum([]byte(text))
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Hmac function, hmac('name') is the hex encoded HMAC-SHA256 with the key added to the configuration under that name
+type Hmac struct {
+	*valueFunction
+}
+
+func (*Hmac) Invoke(nextAndGet PathFunctionNextAndGet, currentPath string, parent common.PathRef, model interface{}, ctx common.EvaluationContext, parameters []*function.Parameter) (interface{}, error) {
+	name, err := stringParameter("hmac", parameters, 0)
+	if err != nil {
+		return nil, err
+	}
+	key, ok := ctx.Configuration().HmacKey(name)
+	if !ok {
+		return nil, &common.JsonPathError{Message: "Function hmac() found no key named: " + name}
+	}
+	text, err := textOf(model, ctx)
+	if err != nil {
+		return nil, err
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(text))
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+// Base64Encode function
+type Base64Encode struct {
+	*valueFunction
+}
+
+func (*Base64Encode) Invoke(nextAndGet PathFunctionNextAndGet, currentPath string, parent common.PathRef, model interface{}, ctx common.EvaluationContext, parameters []*function.Parameter) (interface{}, error) {
+	text, err := textOf(model, ctx)
+	if err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.EncodeToString([]byte(text)), nil
+}
+
+// Base64Decode function
+type Base64Decode struct {
+	*valueFunction
+}
+
+func (*Base64Decode) Invoke(nextAndGet PathFunctionNextAndGet, currentPath string, parent common.PathRef, model interface{}, ctx common.EvaluationContext, parameters []*function.Parameter) (interface{}, error) {
+	str, err := stringModel("base64Decode", model)
+	if err != nil {
+		return nil, err
+	}
+	decoded, err := base64.StdEncoding.DecodeString(str)
+	if err != nil {
+		return nil, &common.JsonPathError{Message: "Function base64Decode() can not decode: " + str}
+	}
+	return string(decoded), nil
+}
+
+// UrlEncode function, encodes a string for a URL query
+type UrlEncode struct {
+	*valueFunction
+}
+
+func (*UrlEncode) Invoke(nextAndGet PathFunctionNextAndGet, currentPath string, parent common.PathRef, model interface{}, ctx common.EvaluationContext, parameters []*function.Parameter) (interface{}, error) {
+	text, err := textOf(model, ctx)
+	if err != nil {
+		return nil, err
+	}
+	return url.QueryEscape(text), nil
+}
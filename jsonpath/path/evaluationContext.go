@@ -17,6 +17,8 @@ type EvaluationContextImpl struct {
 	pathResult        []interface{}
 	suppressException bool
 	resultIndex       int
+	visitedParents    map[parentVisit]bool
+	steps             []pathStep
 }
 
 func (*EvaluationContextImpl) DocumentEvalCache() map[common.Path]interface{} {
@@ -57,7 +59,7 @@ func (e *EvaluationContextImpl) GetValueUnwrap(unwrap bool) (interface{}, error)
 			if e.suppressException {
 				return nil, nil
 			}
-			return nil, &common.PathNotFoundError{Message: "No results for path: " + e.path.String()}
+			return nil, &common.PathNotFoundError{Message: "No results for path: " + e.path.String(), ErrorDetails: common.ErrorDetails{Path: e.path.String()}}
 		}
 		if length, err := e.JsonProvider().Length(e.valueResult); err != nil {
 			return nil, err
@@ -96,6 +98,7 @@ func (e *EvaluationContextImpl) AddResult(pathString string, operation common.Pa
 	}
 
 	e.resultIndex++
+	e.trace(common.TRACE_RESULT_ADDED, nil, pathString, model, false)
 
 	if len(e.configuration.GetEvaluationListeners()) == 0 {
 		idx := e.resultIndex - 1
@@ -109,12 +112,41 @@ func (e *EvaluationContextImpl) AddResult(pathString string, operation common.Pa
 	return nil
 }
 
+// trace sends an event to the tracer of the configuration, the fragment of the token is only built when there is one
+func (e *EvaluationContextImpl) trace(kind common.TraceEventKind, token Token, path string, value interface{}, result bool) {
+	tracer := e.configuration.Tracer()
+	if tracer == nil {
+		return
+	}
+	event := common.TraceEvent{Kind: kind, Path: path, Value: value, Result: result}
+	if token != nil {
+		event.Token = token.GetPathFragment()
+	}
+	tracer.Trace(event)
+}
+
+func (e *EvaluationContextImpl) tracePredicate(token Token, predicate common.Predicate, ctx common.PredicateContext, location common.ItemLocation, result bool) {
+	tracer := e.configuration.Tracer()
+	if tracer == nil {
+		return
+	}
+	tracer.Trace(common.TraceEvent{
+		Kind:             common.TRACE_PREDICATE_EVALUATED,
+		Path:             location.Path(),
+		Token:            token.GetPathFragment(),
+		Value:            ctx.Item(),
+		Result:           result,
+		Predicate:        predicate,
+		PredicateContext: ctx,
+	})
+}
+
 func (e *EvaluationContextImpl) GetPath() (interface{}, error) {
 	if e.resultIndex == 0 {
 		if e.suppressException {
 			return nil, nil
 		}
-		return nil, &common.PathNotFoundError{Message: "No results for path:" + e.path.String()}
+		return nil, &common.PathNotFoundError{Message: "No results for path:" + e.path.String(), ErrorDetails: common.ErrorDetails{Path: e.path.String()}}
 	}
 	return e.pathResult, nil
 }
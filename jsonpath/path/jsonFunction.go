@@ -3,6 +3,7 @@ package path
 import (
 	"github.com/CuiChao512/go-jsonpath/jsonpath/common"
 	"github.com/CuiChao512/go-jsonpath/jsonpath/function"
+	"sort"
 )
 
 type KeySetFunction struct {
@@ -45,3 +46,156 @@ func (*Append) Invoke(nextAndGet PathFunctionNextAndGet, currentPath string, par
 	}
 	return array, nil
 }
+
+func mapModel(name string, model interface{}, ctx common.EvaluationContext) (map[string]interface{}, []string, error) {
+	jsonProvider := ctx.Configuration().JsonProvider()
+	if !jsonProvider.IsMap(model) {
+		return nil, nil, &common.JsonPathError{Message: "Function " + name + "() expects an object, got: " + common.UtilsToString(model)}
+	}
+	keys, err := jsonProvider.GetPropertyKeys(model)
+	if err != nil {
+		return nil, nil, err
+	}
+	sort.Strings(keys)
+	members := jsonProvider.CreateMap()
+	for _, key := range keys {
+		members[key] = jsonProvider.GetMapValue(model, key)
+	}
+	return members, keys, nil
+}
+
+// Values function, the member values of an object ordered by their keys
+type Values struct {
+	*valueFunction
+}
+
+func (*Values) Invoke(nextAndGet PathFunctionNextAndGet, currentPath string, parent common.PathRef, model interface{}, ctx common.EvaluationContext, parameters []*function.Parameter) (interface{}, error) {
+	members, keys, err := mapModel("values", model, ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := ctx.Configuration().JsonProvider().CreateArray()
+	for _, key := range keys {
+		result = append(result, members[key])
+	}
+	return result, nil
+}
+
+// Entries function, the members of an object as {"key": ..., "value": ...} objects ordered by their keys
+type Entries struct {
+	*valueFunction
+}
+
+func (*Entries) Invoke(nextAndGet PathFunctionNextAndGet, currentPath string, parent common.PathRef, model interface{}, ctx common.EvaluationContext, parameters []*function.Parameter) (interface{}, error) {
+	members, keys, err := mapModel("entries", model, ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := ctx.Configuration().JsonProvider().CreateArray()
+	for _, key := range keys {
+		entry := ctx.Configuration().JsonProvider().CreateMap()
+		entry["key"] = key
+		entry["value"] = members[key]
+		result = append(result, entry)
+	}
+	return result, nil
+}
+
+// FromEntries function, the object of an array of {"key": ..., "value": ...} objects or [key, value] pairs
+type FromEntries struct {
+	*valueFunction
+}
+
+func (*FromEntries) Invoke(nextAndGet PathFunctionNextAndGet, currentPath string, parent common.PathRef, model interface{}, ctx common.EvaluationContext, parameters []*function.Parameter) (interface{}, error) {
+	entries, err := arrayModel("fromEntries", model, ctx)
+	if err != nil {
+		return nil, err
+	}
+	jsonProvider := ctx.Configuration().JsonProvider()
+	result := jsonProvider.CreateMap()
+	for _, entry := range entries {
+		key, value := common.JsonProviderUndefined, common.JsonProviderUndefined
+		if jsonProvider.IsMap(entry) {
+			key, value = jsonProvider.GetMapValue(entry, "key"), jsonProvider.GetMapValue(entry, "value")
+		} else if jsonProvider.IsArray(entry) {
+			if pair, err := jsonProvider.ToArray(entry); err == nil && len(pair) == 2 {
+				key, value = pair[0], pair[1]
+			}
+		}
+		name, ok := key.(string)
+		if !ok || value == common.JsonProviderUndefined {
+			return nil, &common.JsonPathError{Message: "Function fromEntries() expects entries with a string key and a value, got: " + common.UtilsToString(entry)}
+		}
+		result[name] = value
+	}
+	return result, nil
+}
+
+// Pick function, pick('a', 'b') keeps the named members of an object
+type Pick struct {
+	*valueFunction
+}
+
+func (*Pick) Invoke(nextAndGet PathFunctionNextAndGet, currentPath string, parent common.PathRef, model interface{}, ctx common.EvaluationContext, parameters []*function.Parameter) (interface{}, error) {
+	members, _, err := mapModel("pick", model, ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := ctx.Configuration().JsonProvider().CreateMap()
+	for i := range parameters {
+		key, err := stringParameter("pick", parameters, i)
+		if err != nil {
+			return nil, err
+		}
+		if value, ok := members[key]; ok {
+			result[key] = value
+		}
+	}
+	return result, nil
+}
+
+// Omit function, omit('secret') drops the named members of an object
+type Omit struct {
+	*valueFunction
+}
+
+func (*Omit) Invoke(nextAndGet PathFunctionNextAndGet, currentPath string, parent common.PathRef, model interface{}, ctx common.EvaluationContext, parameters []*function.Parameter) (interface{}, error) {
+	members, _, err := mapModel("omit", model, ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range parameters {
+		key, err := stringParameter("omit", parameters, i)
+		if err != nil {
+			return nil, err
+		}
+		delete(members, key)
+	}
+	return members, nil
+}
+
+// Merge function, merge($.defaults) combines objects, members of later objects replace members of the same name
+type Merge struct {
+	*valueFunction
+}
+
+func (*Merge) Invoke(nextAndGet PathFunctionNextAndGet, currentPath string, parent common.PathRef, model interface{}, ctx common.EvaluationContext, parameters []*function.Parameter) (interface{}, error) {
+	result, _, err := mapModel("merge", model, ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range parameters {
+		value, err := parameterValue("merge", parameters, i)
+		if err != nil {
+			return nil, err
+		}
+		members, keys, err := mapModel("merge", value, ctx)
+		if err != nil {
+			return nil, &common.InvalidPathError{Message: "Function merge() expects an object as parameter " + common.UtilsToString(i+1)}
+		}
+		for _, key := range keys {
+			result[key] = members[key]
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,126 @@
+package path
+
+import (
+	"github.com/CuiChao512/go-jsonpath/jsonpath/common"
+	"strconv"
+)
+
+// pathStep is a value the evaluation descended into: its evaluated path, the evaluated path of its parent, the property
+// name or array index it has in the parent, the value and its depth in the chain. The root has no parent path.
+type pathStep struct {
+	path   string
+	parent string
+	key    interface{}
+	value  interface{}
+	depth  int
+}
+
+// enterStep records that the evaluation descends into a value, leaveStep that it is done with it. The steps form the
+// chain from the root to the value being evaluated, the parent of a value is looked up there instead of in the document.
+func (e *EvaluationContextImpl) enterStep(path string, parent string, key interface{}, value interface{}) {
+	e.steps = append(e.steps, pathStep{path: path, parent: parent, key: key, value: value, depth: len(e.steps)})
+}
+
+func (e *EvaluationContextImpl) leaveStep() {
+	e.steps = e.steps[:len(e.steps)-1]
+}
+
+// step returns the innermost step with the evaluated path
+func (e *EvaluationContextImpl) step(path string) (pathStep, bool) {
+	for i := len(e.steps) - 1; i >= 0; i-- {
+		if e.steps[i].path == path {
+			return e.steps[i], true
+		}
+	}
+	return pathStep{}, false
+}
+
+// parentStep returns the step of the parent of the value at the evaluated path, the root has no parent
+func (e *EvaluationContextImpl) parentStep(path string) (pathStep, bool) {
+	step, ok := e.step(path)
+	if !ok || step.parent == "" {
+		return pathStep{}, false
+	}
+	return e.step(step.parent)
+}
+
+func (e *EvaluationContextImpl) pathRefOf(step pathStep) common.PathRef {
+	if !e.ForUpdate() {
+		return PathRefNoOp
+	}
+	parent, ok := e.step(step.parent)
+	if !ok {
+		return CreateRootPathRef(e.rootDocument)
+	}
+	switch key := step.key.(type) {
+	case int:
+		return CreateArrayIndexPathRef(parent.value, key)
+	default:
+		return CreateObjectPropertyPathRef(parent.value, common.UtilsToString(key))
+	}
+}
+
+// itemLocation is the common.ItemLocation of an item tested by a filter. The surroundings of an item are looked up in
+// the steps of the evaluation when a filter asks for them.
+type itemLocation struct {
+	ctx         *EvaluationContextImpl
+	path        string
+	parentPath  string
+	parent      interface{}
+	property    interface{}
+	parentKnown bool
+}
+
+func (l *itemLocation) Parent() interface{} {
+	if l.parentKnown {
+		return l.parent
+	}
+	parent, ok := l.ctx.parentStep(l.path)
+	if !ok {
+		return common.JsonProviderUndefined
+	}
+	return parent.value
+}
+
+func (l *itemLocation) Property() interface{} {
+	if l.parentKnown {
+		return l.property
+	}
+	if _, ok := l.ctx.parentStep(l.path); !ok {
+		return common.JsonProviderUndefined
+	}
+	step, _ := l.ctx.step(l.path)
+	return step.key
+}
+
+func (l *itemLocation) Path() string {
+	return l.path
+}
+
+func (l *itemLocation) ParentProperty() interface{} {
+	parentPath := l.parentPath
+	if !l.parentKnown {
+		step, ok := l.ctx.step(l.path)
+		if !ok {
+			return common.JsonProviderUndefined
+		}
+		parentPath = step.parent
+	}
+	if _, ok := l.ctx.parentStep(parentPath); !ok {
+		return common.JsonProviderUndefined
+	}
+	parent, _ := l.ctx.step(parentPath)
+	return parent.key
+}
+
+func createElementLocation(ctx *EvaluationContextImpl, arrayPath string, array interface{}, index int) *itemLocation {
+	return &itemLocation{ctx: ctx, path: arrayPath + "[" + strconv.Itoa(index) + "]", parentPath: arrayPath, parent: array, property: index, parentKnown: true}
+}
+
+func createMemberLocation(ctx *EvaluationContextImpl, objectPath string, object interface{}, key string) *itemLocation {
+	return &itemLocation{ctx: ctx, path: objectPath + "['" + key + "']", parentPath: objectPath, parent: object, property: key, parentKnown: true}
+}
+
+func createItemLocation(ctx *EvaluationContextImpl, itemPath string) *itemLocation {
+	return &itemLocation{ctx: ctx, path: itemPath}
+}
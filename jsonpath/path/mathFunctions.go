@@ -0,0 +1,184 @@
+package path
+
+import (
+	"github.com/CuiChao512/go-jsonpath/jsonpath/common"
+	"github.com/CuiChao512/go-jsonpath/jsonpath/function"
+	"math"
+
+	"github.com/shopspring/decimal"
+)
+
+func numberParameter(name string, parameters []*function.Parameter, index int) (decimal.Decimal, error) {
+	value, err := parameterValue(name, parameters, index)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	number, ok := common.UtilsCoerceDecimal(value)
+	if !ok {
+		return decimal.Zero, &common.InvalidPathError{Message: "Function " + name + "() expects a number as parameter " + common.UtilsToString(index+1)}
+	}
+	return number, nil
+}
+
+// applyMath applies an operation to a number, or to every item of an array of numbers. Operations work on decimals so
+// that round(2) of 2.675 is 2.68, results are float64 like those of the other numeric functions. Items of an array that
+// are not numbers are skipped like the aggregates do, unless OPTION_STRICT_NUMBERS is set.
+func applyMath(name string, model interface{}, ctx common.EvaluationContext, operation func(decimal.Decimal) (decimal.Decimal, error)) (interface{}, error) {
+	apply := func(number decimal.Decimal) (interface{}, error) {
+		result, err := operation(number)
+		if err != nil {
+			return nil, err
+		}
+		f, _ := result.Float64()
+		return f, nil
+	}
+	if !ctx.Configuration().JsonProvider().IsArray(model) {
+		number, ok := common.UtilsCoerceDecimal(model)
+		if !ok {
+			return nil, &common.JsonPathError{Message: "Function " + name + "() expects a number, got: " + common.UtilsToString(model)}
+		}
+		return apply(number)
+	}
+	items, err := ctx.Configuration().JsonProvider().ToArray(model)
+	if err != nil {
+		return nil, err
+	}
+	strict := common.UtilsSliceContains(ctx.Configuration().Options(), common.OPTION_STRICT_NUMBERS)
+	result := ctx.Configuration().JsonProvider().CreateArray()
+	for _, item := range items {
+		number, ok := common.UtilsCoerceDecimal(item)
+		if !ok {
+			if strict {
+				return nil, &common.JsonPathError{Message: "Function " + name + "() expects numbers, got: " + common.UtilsToString(item)}
+			}
+			continue
+		}
+		value, err := apply(number)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, value)
+	}
+	return result, nil
+}
+
+// Abs function
+type Abs struct {
+	*valueFunction
+}
+
+func (*Abs) Invoke(nextAndGet PathFunctionNextAndGet, currentPath string, parent common.PathRef, model interface{}, ctx common.EvaluationContext, parameters []*function.Parameter) (interface{}, error) {
+	return applyMath("abs", model, ctx, func(number decimal.Decimal) (decimal.Decimal, error) {
+		return number.Abs(), nil
+	})
+}
+
+// Round function, round() or round(places), halves are rounded away from zero
+type Round struct {
+	*valueFunction
+}
+
+func (*Round) Invoke(nextAndGet PathFunctionNextAndGet, currentPath string, parent common.PathRef, model interface{}, ctx common.EvaluationContext, parameters []*function.Parameter) (interface{}, error) {
+	places := 0
+	if len(parameters) > 0 {
+		var err error
+		if places, err = intParameter("round", parameters, 0); err != nil {
+			return nil, err
+		}
+	}
+	return applyMath("round", model, ctx, func(number decimal.Decimal) (decimal.Decimal, error) {
+		return number.Round(int32(places)), nil
+	})
+}
+
+// Floor function
+type Floor struct {
+	*valueFunction
+}
+
+func (*Floor) Invoke(nextAndGet PathFunctionNextAndGet, currentPath string, parent common.PathRef, model interface{}, ctx common.EvaluationContext, parameters []*function.Parameter) (interface{}, error) {
+	return applyMath("floor", model, ctx, func(number decimal.Decimal) (decimal.Decimal, error) {
+		return number.Floor(), nil
+	})
+}
+
+// Ceil function
+type Ceil struct {
+	*valueFunction
+}
+
+func (*Ceil) Invoke(nextAndGet PathFunctionNextAndGet, currentPath string, parent common.PathRef, model interface{}, ctx common.EvaluationContext, parameters []*function.Parameter) (interface{}, error) {
+	return applyMath("ceil", model, ctx, func(number decimal.Decimal) (decimal.Decimal, error) {
+		return number.Ceil(), nil
+	})
+}
+
+// Pow function, pow(exponent), small integer exponents are computed exactly
+type Pow struct {
+	*valueFunction
+}
+
+// maxExactExponent bounds the integer exponents computed exactly, larger ones are computed on floats
+const maxExactExponent = 64
+
+func (*Pow) Invoke(nextAndGet PathFunctionNextAndGet, currentPath string, parent common.PathRef, model interface{}, ctx common.EvaluationContext, parameters []*function.Parameter) (interface{}, error) {
+	exponent, err := numberParameter("pow", parameters, 0)
+	if err != nil {
+		return nil, err
+	}
+	return applyMath("pow", model, ctx, func(number decimal.Decimal) (decimal.Decimal, error) {
+		if exponent.IsInteger() && exponent.Abs().LessThanOrEqual(decimal.NewFromInt(maxExactExponent)) &&
+			!(number.IsZero() && exponent.IsNegative()) {
+			result := number.Pow(exponent)
+			f, _ := result.Float64()
+			if _, err := floatResult("pow", f); err != nil {
+				return decimal.Zero, err
+			}
+			return result, nil
+		}
+		base, _ := number.Float64()
+		power, _ := exponent.Float64()
+		return floatResult("pow", math.Pow(base, power))
+	})
+}
+
+// Sqrt function
+type Sqrt struct {
+	*valueFunction
+}
+
+func (*Sqrt) Invoke(nextAndGet PathFunctionNextAndGet, currentPath string, parent common.PathRef, model interface{}, ctx common.EvaluationContext, parameters []*function.Parameter) (interface{}, error) {
+	return applyMath("sqrt", model, ctx, func(number decimal.Decimal) (decimal.Decimal, error) {
+		f, _ := number.Float64()
+		return floatResult("sqrt", math.Sqrt(f))
+	})
+}
+
+func floatResult(name string, f float64) (decimal.Decimal, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return decimal.Zero, &common.JsonPathError{Message: "Function " + name + "() has no result that is a number"}
+	}
+	return decimal.NewFromFloat(f), nil
+}
+
+// Clamp function, clamp(min, max) limits numbers to a range
+type Clamp struct {
+	*valueFunction
+}
+
+func (*Clamp) Invoke(nextAndGet PathFunctionNextAndGet, currentPath string, parent common.PathRef, model interface{}, ctx common.EvaluationContext, parameters []*function.Parameter) (interface{}, error) {
+	lower, err := numberParameter("clamp", parameters, 0)
+	if err != nil {
+		return nil, err
+	}
+	upper, err := numberParameter("clamp", parameters, 1)
+	if err != nil {
+		return nil, err
+	}
+	if lower.GreaterThan(upper) {
+		return nil, &common.InvalidPathError{Message: "Function clamp() expects a minimum that is not greater than the maximum"}
+	}
+	return applyMath("clamp", model, ctx, func(number decimal.Decimal) (decimal.Decimal, error) {
+		return decimal.Min(decimal.Max(number, lower), upper), nil
+	})
+}
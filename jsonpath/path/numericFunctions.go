@@ -4,6 +4,7 @@ import (
 	"github.com/CuiChao512/go-jsonpath/jsonpath/common"
 	"github.com/CuiChao512/go-jsonpath/jsonpath/function"
 	"math"
+	"sort"
 	"strings"
 )
 
@@ -11,43 +12,54 @@ type defaultInvoker struct {
 }
 
 func (a *defaultInvoker) Invoke(nextAndGet PathFunctionNextAndGet, currentPath string, parent common.PathRef, model interface{}, ctx common.EvaluationContext, parameters []*function.Parameter) (interface{}, error) {
+	count, err := a.aggregate(nextAndGet, model, ctx, parameters)
+	if err != nil {
+		return nil, err
+	}
+	if count != 0 {
+		return nextAndGet.GetValue(), nil
+	}
+	return nil, &common.JsonPathError{Message: "Aggregation function attempted to calculate value using empty array"}
+}
+
+// aggregate passes the numbers of the array and of the parameters to the function and returns how many there were
+func (a *defaultInvoker) aggregate(nextAndGet PathFunctionNextAndGet, model interface{}, ctx common.EvaluationContext, parameters []*function.Parameter) (int, error) {
 	count := 0
+	strict := common.UtilsSliceContains(ctx.Configuration().Options(), common.OPTION_STRICT_NUMBERS)
 	if ctx.Configuration().JsonProvider().IsArray(model) {
 
 		objects, err := ctx.Configuration().JsonProvider().ToArray(model)
 		if err != nil {
-			return nil, err
+			return 0, err
 		}
 		for _, obj := range objects {
-			isNumber := false
-			switch obj.(type) {
-			case int:
-				isNumber = true
-			case float64:
-				isNumber = true
-			case float32:
-				isNumber = true
-			}
-			if isNumber {
+			if number, ok := common.UtilsCoerceNumber(obj); ok {
 				count++
-				nextAndGet.Next(obj)
+				nextAndGet.Next(number)
+			} else if strict {
+				return 0, notANumberError(obj)
 			}
 		}
 	}
 	if parameters != nil {
 		values, err := function.ParametersToList(common.TYPE_NUMBER, ctx, parameters)
 		if err != nil {
-			return nil, err
+			return 0, err
 		}
 		for _, value := range values {
-			count++
-			nextAndGet.Next(value)
+			if number, ok := common.UtilsCoerceNumber(value); ok {
+				count++
+				nextAndGet.Next(number)
+			} else if strict {
+				return 0, notANumberError(value)
+			}
 		}
 	}
-	if count != 0 {
-		return nextAndGet.GetValue(), nil
-	}
-	return nil, &common.JsonPathError{Message: "Aggregation function attempted to calculate value using empty array"}
+	return count, nil
+}
+
+func notANumberError(value interface{}) error {
+	return &common.JsonPathError{Message: "Aggregation function expects numbers, got: " + common.UtilsToString(value)}
 }
 
 // Average function
@@ -148,6 +160,135 @@ func (s *Sum) GetValue() interface{} {
 	return s.sum
 }
 
+// Variance function, the population variance like stddev
+type Variance struct {
+	*defaultInvoker
+	sumSq float64
+	sum   float64
+	count int64
+}
+
+func (v *Variance) Next(value interface{}) {
+	f := common.UtilsNumberToFloat64Force(value)
+	v.sum += f
+	v.sumSq += f * f
+	v.count++
+}
+
+func (v *Variance) GetValue() interface{} {
+	count := float64(v.count)
+	return v.sumSq/count - v.sum*v.sum/count/count
+}
+
+// Count function, the number of numeric items
+type Count struct {
+	*defaultInvoker
+	count int
+}
+
+func (c *Count) Next(value interface{}) {
+	c.count++
+}
+
+func (c *Count) GetValue() interface{} {
+	return c.count
+}
+
+func (c *Count) Invoke(nextAndGet PathFunctionNextAndGet, currentPath string, parent common.PathRef, model interface{}, ctx common.EvaluationContext, parameters []*function.Parameter) (interface{}, error) {
+	// nothing to count is not an error
+	if _, err := c.aggregate(nextAndGet, model, ctx, parameters); err != nil {
+		return nil, err
+	}
+	return c.count, nil
+}
+
+// CountDistinct function, the number of different numeric items
+type CountDistinct struct {
+	*defaultInvoker
+	seen map[float64]bool
+}
+
+func (c *CountDistinct) Next(value interface{}) {
+	c.seen[common.UtilsNumberToFloat64Force(value)] = true
+}
+
+func (c *CountDistinct) GetValue() interface{} {
+	return len(c.seen)
+}
+
+func CreateCountDistinctFunction() *CountDistinct {
+	return &CountDistinct{seen: map[float64]bool{}}
+}
+
+// Percentile function, percentile(p) interpolates between the closest ranks like spreadsheets do
+type Percentile struct {
+	*defaultInvoker
+	percentile float64
+	values     []float64
+}
+
+func (p *Percentile) Next(value interface{}) {
+	p.values = append(p.values, common.UtilsNumberToFloat64Force(value))
+}
+
+func (p *Percentile) GetValue() interface{} {
+	sort.Float64s(p.values)
+	rank := p.percentile * float64(len(p.values)-1) / 100
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	return p.values[lower] + (rank-float64(lower))*(p.values[upper]-p.values[lower])
+}
+
+func (p *Percentile) Invoke(nextAndGet PathFunctionNextAndGet, currentPath string, parent common.PathRef, model interface{}, ctx common.EvaluationContext, parameters []*function.Parameter) (interface{}, error) {
+	value, err := parameterValue("percentile", parameters, 0)
+	if err != nil {
+		return nil, err
+	}
+	percentile, err := common.UtilsNumberToFloat64(value)
+	if err != nil || percentile < 0 || percentile > 100 {
+		return nil, &common.InvalidPathError{Message: "Function percentile() expects a number from 0 to 100, got: " + common.UtilsToString(value)}
+	}
+	p.percentile = percentile
+	return p.defaultInvoker.Invoke(nextAndGet, currentPath, parent, model, ctx, parameters[1:])
+}
+
+// Median function, the 50th percentile
+type Median struct {
+	*Percentile
+}
+
+func (m *Median) Invoke(nextAndGet PathFunctionNextAndGet, currentPath string, parent common.PathRef, model interface{}, ctx common.EvaluationContext, parameters []*function.Parameter) (interface{}, error) {
+	return m.defaultInvoker.Invoke(nextAndGet, currentPath, parent, model, ctx, parameters)
+}
+
+func CreateMedianFunction() *Median {
+	return &Median{Percentile: &Percentile{percentile: 50}}
+}
+
+// Mode function, the most frequent item, the smallest one of equally frequent items
+type Mode struct {
+	*defaultInvoker
+	counts map[float64]int
+}
+
+func (m *Mode) Next(value interface{}) {
+	m.counts[common.UtilsNumberToFloat64Force(value)]++
+}
+
+func (m *Mode) GetValue() interface{} {
+	mode, modeCount := 0.0, 0
+	for value, count := range m.counts {
+		if count > modeCount || count == modeCount && value < mode {
+			mode, modeCount = value, count
+		}
+	}
+	return mode
+}
+
+func CreateModeFunction() *Mode {
+	return &Mode{counts: map[float64]int{}}
+}
+
 type Length struct {
 }
 
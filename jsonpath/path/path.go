@@ -35,7 +35,7 @@ func (*noOpPathRef) RenameKey(oldKeyName string, newKeyName string, configuratio
 func renameInMap(targetMap interface{}, oldKeyName string, newKeyName string, config *common.Configuration) error {
 	if config.JsonProvider().IsMap(targetMap) {
 		if config.JsonProvider().GetMapValue(targetMap, oldKeyName) == common.JsonProviderUndefined {
-			return &common.PathNotFoundError{Message: "No results for Key " + oldKeyName + " found in map!"}
+			return &common.PathNotFoundError{Message: "No results for Key " + oldKeyName + " found in map!", ErrorDetails: common.ErrorDetails{ErrorCode: common.CodeMissingProperty}}
 		}
 		err := config.JsonProvider().SetProperty(&targetMap, newKeyName, config.JsonProvider().GetMapValue(targetMap, oldKeyName))
 		if err != nil {
@@ -32,6 +32,32 @@ func GetFunctionByName(name string) (PathFunction, error) {
 		f = CreateMinFunction()
 	case "max":
 		f = CreateMaxFunction()
+	case "variance":
+		f = &Variance{}
+	case "count":
+		f = &Count{}
+	case "countDistinct":
+		f = CreateCountDistinctFunction()
+	case "median":
+		f = CreateMedianFunction()
+	case "percentile":
+		f = &Percentile{}
+	case "mode":
+		f = CreateModeFunction()
+	case "abs":
+		f = &Abs{}
+	case "round":
+		f = &Round{}
+	case "floor":
+		f = &Floor{}
+	case "ceil":
+		f = &Ceil{}
+	case "pow":
+		f = &Pow{}
+	case "sqrt":
+		f = &Sqrt{}
+	case "clamp":
+		f = &Clamp{}
 	case "concat":
 		f = &Concatenate{}
 	case "length":
@@ -42,9 +68,105 @@ func GetFunctionByName(name string) (PathFunction, error) {
 		f = &Append{}
 	case "keys":
 		f = &KeySetFunction{}
+	case "values":
+		f = &Values{}
+	case "entries":
+		f = &Entries{}
+	case "fromEntries":
+		f = &FromEntries{}
+	case "pick":
+		f = &Pick{}
+	case "omit":
+		f = &Omit{}
+	case "merge":
+		f = &Merge{}
+	case "lower":
+		f = &Lower{}
+	case "upper":
+		f = &Upper{}
+	case "trim":
+		f = &Trim{}
+	case "substring":
+		f = &Substring{}
+	case "replace":
+		f = &Replace{}
+	case "split":
+		f = &Split{}
+	case "join":
+		f = &Join{}
+	case "startsWith":
+		f = &StartsWith{}
+	case "endsWith":
+		f = &EndsWith{}
+	case "indexOf":
+		f = &IndexOf{}
+	case "toNumber":
+		f = &ToNumber{}
+	case "toString":
+		f = &ToString{}
+	case "toBoolean":
+		f = &ToBoolean{}
+	case "type":
+		f = &Type{}
+	case "isNumber":
+		f = CreateIsTypeFunction("number")
+	case "isString":
+		f = CreateIsTypeFunction("string")
+	case "isArray":
+		f = CreateIsTypeFunction("array")
+	case "isObject":
+		f = CreateIsTypeFunction("object")
+	case "sha256":
+		f = &Sha256{}
+	case "md5":
+		f = &Md5{}
+	case "hmac":
+		f = &Hmac{}
+	case "base64Encode":
+		f = &Base64Encode{}
+	case "base64Decode":
+		f = &Base64Decode{}
+	case "urlEncode":
+		f = &UrlEncode{}
+	case "now":
+		f = &Now{}
+	case "parseDate":
+		f = &ParseDate{}
+	case "formatDate":
+		f = &FormatDate{}
+	case "epochMillis":
+		f = &EpochMillis{}
+	case "dateAdd":
+		f = &DateAdd{}
+	case "dateDiff":
+		f = &DateDiff{}
+	case "truncate":
+		f = &Truncate{}
+	case "first":
+		f = &First{}
+	case "last":
+		f = &Last{}
+	case "index":
+		f = &Index{}
+	case "distinct":
+		f = &Distinct{}
+	case "reverse":
+		f = &Reverse{}
+	case "sort":
+		f = &Sort{}
+	case "flatten":
+		f = &Flatten{}
 	default:
 		return nil, &common.InvalidPathError{Message: "Function with name: " + name + " does not exist."}
 	}
 
 	return f, nil
 }
+
+// collectingFunctions work on a whole array. Following an indefinite path, like $.events[*].type.distinct(), they are
+// applied to all results of the path at once instead of to each of them.
+var collectingFunctions = []string{"first", "last", "index", "distinct", "reverse", "sort", "flatten"}
+
+func isCollectingFunction(name string) bool {
+	return common.UtilsSliceContains(collectingFunctions, name)
+}
@@ -0,0 +1,187 @@
+package path
+
+import (
+	"github.com/CuiChao512/go-jsonpath/jsonpath/common"
+)
+
+// CompiledPathSet evaluates many paths in one traversal of a document. The paths are kept in a trie of their leading
+// single property tokens, a property shared by several paths is read once. Deep scans that start at the same node share
+// one walk of the node.
+type CompiledPathSet struct {
+	paths []*CompiledPath
+	roots []*pathSetNode
+}
+
+// pathSetNode is a node reached by reading the properties from the root to it. Entries are the paths that go on with
+// a token that is not shared, children are reached by reading one more property.
+type pathSetNode struct {
+	rootToken string
+	property  string
+	entries   []pathSetEntry
+	children  []*pathSetNode
+}
+
+// pathSetEntry is the token a path evaluates at a node, for a child it is the property token that leads to the child
+type pathSetEntry struct {
+	index int
+	token Token
+}
+
+func (n *pathSetNode) child(property string) *pathSetNode {
+	for _, child := range n.children {
+		if child.property == property {
+			return child
+		}
+	}
+	child := &pathSetNode{property: property}
+	n.children = append(n.children, child)
+	return child
+}
+
+// leadingTokens returns the property tokens of the paths that read the property of n
+func (n *pathSetNode) leadingTokens() []pathSetEntry {
+	var entries []pathSetEntry
+	n.collect(func(entry pathSetEntry, depth int) {
+		token := entry.token
+		for i := 0; i < depth; i++ {
+			token = token.prevToken()
+		}
+		entries = append(entries, pathSetEntry{index: entry.index, token: token})
+	}, 1)
+	return entries
+}
+
+func (n *pathSetNode) collect(found func(entry pathSetEntry, depth int), depth int) {
+	for _, entry := range n.entries {
+		found(entry, depth)
+	}
+	for _, child := range n.children {
+		child.collect(found, depth+1)
+	}
+}
+
+func isSharedToken(token Token) bool {
+	property, ok := token.(*PropertyPathToken)
+	return ok && property.SinglePropertyCase() && !property.isLeaf()
+}
+
+func CreateCompiledPathSet(paths []*CompiledPath) *CompiledPathSet {
+	set := &CompiledPathSet{paths: paths}
+	for i, path := range paths {
+		var node *pathSetNode
+		for _, root := range set.roots {
+			if root.rootToken == path.root.rootToken {
+				node = root
+			}
+		}
+		if node == nil {
+			node = &pathSetNode{rootToken: path.root.rootToken}
+			set.roots = append(set.roots, node)
+		}
+		var token Token = path.root
+		if !path.root.isLeaf() {
+			token = path.root.GetNext()
+			for isSharedToken(token) {
+				node = node.child(token.(*PropertyPathToken).properties[0])
+				token = token.GetNext()
+			}
+		}
+		node.entries = append(node.entries, pathSetEntry{index: i, token: token})
+	}
+	return set
+}
+
+// Evaluate evaluates all paths of the set, the contexts and errors are in the order of the paths. A path that fails
+// does not stop the evaluation of the others.
+func (s *CompiledPathSet) Evaluate(document interface{}, configuration *common.Configuration) ([]common.EvaluationContext, []error) {
+	e := &pathSetEvaluation{
+		contexts: make([]*EvaluationContextImpl, len(s.paths)),
+		errs:     make([]error, len(s.paths)),
+		provider: configuration.JsonProvider(),
+	}
+	for i, path := range s.paths {
+		e.contexts[i] = CreateEvaluationContextImpl(path, document, configuration, false)
+	}
+	for _, root := range s.roots {
+		e.enterStep(root.rootToken, "", nil, document)
+		e.evaluateNode(root, root.rootToken, document)
+		e.leaveStep()
+	}
+	results := make([]common.EvaluationContext, len(e.contexts))
+	for i, ctx := range e.contexts {
+		if e.errs[i] == nil {
+			results[i] = ctx
+		}
+	}
+	return results, e.errs
+}
+
+type pathSetEvaluation struct {
+	contexts []*EvaluationContextImpl
+	errs     []error
+	provider common.JsonProvider
+}
+
+// traceRead traces a shared property read once, with the token of the first path that reads it
+func (e *pathSetEvaluation) traceRead(node *pathSetNode, currentPath string, value interface{}) {
+	if len(e.contexts) == 0 || e.contexts[0].configuration.Tracer() == nil {
+		return
+	}
+	entry := node.leadingTokens()[0]
+	e.contexts[entry.index].trace(common.TRACE_PROPERTY_READ, entry.token, currentPath, value, false)
+}
+
+// enterStep enters a step of the shared traversal in the contexts of all paths
+func (e *pathSetEvaluation) enterStep(path string, parent string, key interface{}, value interface{}) {
+	for _, ctx := range e.contexts {
+		ctx.enterStep(path, parent, key, value)
+	}
+}
+
+func (e *pathSetEvaluation) leaveStep() {
+	for _, ctx := range e.contexts {
+		ctx.leaveStep()
+	}
+}
+
+func (e *pathSetEvaluation) evaluateNode(node *pathSetNode, currentPath string, model interface{}) {
+	var targets []*scanTarget
+	var scanned []int
+	for _, entry := range node.entries {
+		if scan, ok := entry.token.(*ScanPathToken); ok {
+			target, err := scan.scanTarget(e.contexts[entry.index])
+			if err != nil {
+				e.errs[entry.index] = err
+				continue
+			}
+			targets = append(targets, target)
+			scanned = append(scanned, entry.index)
+			continue
+		}
+		e.errs[entry.index] = entry.token.Evaluate(currentPath, PathRefNoOp, model, e.contexts[entry.index])
+	}
+	if len(targets) > 0 {
+		scanWalk(targets, currentPath, PathRefNoOp, model, e.provider)
+		for i, target := range targets {
+			e.errs[scanned[i]] = target.err
+		}
+	}
+
+	for _, child := range node.children {
+		if e.provider.IsMap(model) {
+			value := e.provider.GetMapValue(model, child.property)
+			if value != common.JsonProviderUndefined {
+				childPath := common.UtilsConcat(currentPath, "['", child.property, "']")
+				e.traceRead(child, childPath, value)
+				e.enterStep(childPath, currentPath, child.property, value)
+				e.evaluateNode(child, childPath, value)
+				e.leaveStep()
+				continue
+			}
+		}
+		// the property is missing, each path handles it like it does when it is evaluated alone
+		for _, entry := range child.leadingTokens() {
+			e.errs[entry.index] = entry.token.Evaluate(currentPath, PathRefNoOp, model, e.contexts[entry.index])
+		}
+	}
+}
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"github.com/CuiChao512/go-jsonpath/jsonpath/common"
 	"github.com/CuiChao512/go-jsonpath/jsonpath/function"
-	"log"
 	"reflect"
 	"strconv"
 )
@@ -133,7 +132,7 @@ func tokenHandleObjectProperty(dt Token, currentPath string, model interface{},
 		property := properties[0]
 		evalPath := common.UtilsConcat(currentPath, "['", property, "']")
 		propertyVal := pathTokenReadObjectProperty(property, model, ctx)
-		fmt.Println("propertyVal:", common.UtilsToString(propertyVal))
+		ctx.trace(common.TRACE_PROPERTY_READ, dt, evalPath, propertyVal, false)
 		if propertyVal == common.JsonProviderUndefined {
 			// Conditions below heavily depend on current token type (and its logic) and are not "universal",
 			// so this code is quite dangerous (I'd rather rewrite it & move to PropertyPathToken and implemented
@@ -154,7 +153,7 @@ func tokenHandleObjectProperty(dt Token, currentPath string, model interface{},
 						common.UtilsSliceContains(ctx.Options(), common.OPTION_SUPPRESS_EXCEPTIONS) {
 						return nil
 					} else {
-						return &common.PathNotFoundError{Message: "No results for path: " + evalPath}
+						return &common.PathNotFoundError{Message: "No results for path: " + evalPath, ErrorDetails: common.ErrorDetails{Location: evalPath, ErrorCode: common.CodeMissingProperty}}
 					}
 				}
 			} else {
@@ -166,7 +165,7 @@ func tokenHandleObjectProperty(dt Token, currentPath string, model interface{},
 					// branches could be examined.
 					return nil
 				} else {
-					return &common.PathNotFoundError{Message: "Missing property in path " + evalPath}
+					return &common.PathNotFoundError{Message: "Missing property in path " + evalPath, ErrorDetails: common.ErrorDetails{Location: evalPath, ErrorCode: common.CodeMissingProperty}}
 				}
 			}
 		}
@@ -196,7 +195,9 @@ func tokenHandleObjectProperty(dt Token, currentPath string, model interface{},
 			}
 		} else {
 			next, _ := dt.nextToken()
+			ctx.enterStep(evalPath, currentPath, property, propertyVal)
 			err := next.Evaluate(evalPath, ref, propertyVal, ctx)
+			ctx.leaveStep()
 			if err != nil {
 				return err
 			}
@@ -217,6 +218,9 @@ func tokenHandleObjectProperty(dt Token, currentPath string, model interface{},
 			}
 			if tokenHasProperty {
 				propertyVal = pathTokenReadObjectProperty(property, model, ctx)
+				if ctx.Configuration().Tracer() != nil {
+					ctx.trace(common.TRACE_PROPERTY_READ, dt, common.UtilsConcat(currentPath, "['", property, "']"), propertyVal, false)
+				}
 				if propertyVal == common.JsonProviderUndefined {
 					if common.UtilsSliceContains(ctx.Options(), common.OPTION_DEFAULT_PATH_LEAF_TO_NULL) {
 						propertyVal = nil
@@ -228,7 +232,7 @@ func tokenHandleObjectProperty(dt Token, currentPath string, model interface{},
 				if common.UtilsSliceContains(ctx.Options(), common.OPTION_DEFAULT_PATH_LEAF_TO_NULL) {
 					propertyVal = nil
 				} else if common.UtilsSliceContains(ctx.Options(), common.OPTION_REQUIRE_PROPERTIES) {
-					return &common.PathNotFoundError{Message: "Missing property in path " + evalPath}
+					return &common.PathNotFoundError{Message: "Missing property in path " + evalPath, ErrorDetails: common.ErrorDetails{Location: evalPath, ErrorCode: common.CodeMissingProperty}}
 				} else {
 					continue
 				}
@@ -300,6 +304,8 @@ func (r *defaultToken) handleArrayIndex(index int, currentPath string, model int
 		if err != nil {
 			return err
 		}
+		ctx.enterStep(evalPath, currentPath, effectiveIndex, evalHit)
+		defer ctx.leaveStep()
 		return next.Evaluate(evalPath, pathRef, evalHit, ctx)
 	}
 	return nil
@@ -395,6 +401,7 @@ func (r *RootPathToken) GetPathTokenAppender() TokenAppender {
 }
 
 func (r *RootPathToken) Evaluate(currentPath string, ref common.PathRef, model interface{}, ctx *EvaluationContextImpl) error {
+	ctx.trace(common.TRACE_TOKEN_ENTERED, r, r.rootToken, model, false)
 	if r.isLeaf() {
 		var op common.PathRef
 		if ctx.ForUpdate() {
@@ -405,7 +412,9 @@ func (r *RootPathToken) Evaluate(currentPath string, ref common.PathRef, model i
 		return ctx.AddResult(r.rootToken, op, model)
 	} else {
 		next, _ := r.nextToken()
+		ctx.enterStep(r.rootToken, "", nil, model)
 		err := next.Evaluate(r.rootToken, ref, model, ctx)
+		ctx.leaveStep()
 		if err != nil {
 			return err
 		}
@@ -456,6 +465,8 @@ type FunctionPathToken struct {
 	functionName   string
 	pathFragment   string
 	functionParams []*function.Parameter
+	// source is the path whose results are collected and passed to the function, see invertScannerFunctionRelationship
+	source common.Path
 }
 
 func (f *FunctionPathToken) String() string {
@@ -479,14 +490,41 @@ func (f *FunctionPathToken) IsTokenDefinite() bool {
 }
 
 func (f *FunctionPathToken) GetPathFragment() string {
+	if f.source != nil {
+		return f.source.String()[1:] + "." + f.pathFragment
+	}
 	return "." + f.pathFragment
 }
 
+func (f *FunctionPathToken) GetFunctionName() string {
+	return f.functionName
+}
+
+func (f *FunctionPathToken) GetParameters() []*function.Parameter {
+	return f.functionParams
+}
+
+// GetSource is the path whose results are passed to the function at once, or nil when the function is applied to
+// the result of the tokens before it
+func (f *FunctionPathToken) GetSource() common.Path {
+	return f.source
+}
+
 func (f *FunctionPathToken) Evaluate(currentPath string, parent common.PathRef, model interface{}, ctx *EvaluationContextImpl) error {
+	ctx.trace(common.TRACE_TOKEN_ENTERED, f, currentPath, model, false)
 	pathFunction, err := GetFunctionByName(f.functionName)
 	if err != nil {
 		return err
 	}
+	if f.source != nil {
+		sourceCtx, err := f.source.Evaluate(model, ctx.RootDocument(), ctx.Configuration())
+		if err != nil {
+			return err
+		}
+		if model, err = sourceCtx.GetValue(); err != nil {
+			return err
+		}
+	}
 	err = f.evaluateParameters(currentPath, parent, model, ctx)
 	if err != nil {
 		return err
@@ -495,11 +533,12 @@ func (f *FunctionPathToken) Evaluate(currentPath string, parent common.PathRef,
 	if err != nil {
 		return err
 	}
-	if err = ctx.AddResult(currentPath+"."+f.functionName, parent, result); err != nil {
-		return err
-	}
 	f.cleanWildcardPathToken()
-	if !f.isLeaf() {
+	if f.isLeaf() {
+		if err = ctx.AddResult(currentPath+"."+f.functionName, parent, result); err != nil {
+			return err
+		}
+	} else {
 		next, _ := f.nextToken()
 		err = next.Evaluate(currentPath, parent, result, ctx)
 		if err != nil {
@@ -634,6 +673,7 @@ func (p *PropertyPathToken) GetPathFragment() string {
 }
 
 func (p *PropertyPathToken) Evaluate(currentPath string, parent common.PathRef, model interface{}, ctx *EvaluationContextImpl) error {
+	ctx.trace(common.TRACE_TOKEN_ENTERED, p, currentPath, model, false)
 	var truthCount int = 0
 	if p.SinglePropertyCase() {
 		truthCount++
@@ -661,7 +701,7 @@ func (p *PropertyPathToken) Evaluate(currentPath string, parent common.PathRef,
 			message := fmt.Sprintf("Expected to find an object with property %s in path %s but found '%s'. "+
 				"This is not a json object according to the JsonProvider: '%s'.",
 				p.GetPathFragment(), currentPath, m, reflect.TypeOf(common.UtilsGetPtrElem(ctx.Configuration().JsonProvider())).Name())
-			return &common.PathNotFoundError{Message: message}
+			return &common.PathNotFoundError{Message: message, ErrorDetails: common.ErrorDetails{Location: currentPath, ErrorCode: common.CodeNotAnObject}}
 		}
 	}
 
@@ -718,6 +758,7 @@ func (w *WildcardPathToken) GetPathFragment() string {
 }
 
 func (w *WildcardPathToken) Evaluate(currentPath string, parent common.PathRef, model interface{}, ctx *EvaluationContextImpl) error {
+	ctx.trace(common.TRACE_TOKEN_ENTERED, w, currentPath, model, false)
 	if ctx.JsonProvider().IsMap(model) {
 		propertyKeys, err := ctx.JsonProvider().GetPropertyKeys(model)
 		if err != nil {
@@ -755,12 +796,12 @@ func CreateWildcardPathToken() *WildcardPathToken {
 type defaultScanPredicate struct {
 }
 
-func (*defaultScanPredicate) matches(model interface{}) (bool, error) {
+func (*defaultScanPredicate) matches(currentPath string, model interface{}) (bool, error) {
 	return false, nil
 }
 
 type ScanPredicate interface {
-	matches(model interface{}) (bool, error)
+	matches(currentPath string, model interface{}) (bool, error)
 }
 
 type filterPathTokenPredicate struct {
@@ -768,8 +809,18 @@ type filterPathTokenPredicate struct {
 	predicatePathToken *PredicatePathToken
 }
 
-func (f *filterPathTokenPredicate) matches(model interface{}) (bool, error) {
-	return f.predicatePathToken.accept(model, f.ctx.RootDocument(), f.ctx.Configuration(), f.ctx)
+func (f *filterPathTokenPredicate) matches(currentPath string, model interface{}) (bool, error) {
+	if isMemberFilter(f.predicatePathToken, f.ctx) {
+		return f.ctx.JsonProvider().IsMap(model) || f.ctx.JsonProvider().IsArray(model), nil
+	}
+	return f.predicatePathToken.accept(model, f.ctx.RootDocument(), f.ctx.Configuration(), f.ctx, createItemLocation(f.ctx, currentPath))
+}
+
+// isMemberFilter reports whether pt is a filter that tests the children of the node it is applied to, whether the
+// node is an array or an object.
+func isMemberFilter(pt Token, ctx *EvaluationContextImpl) bool {
+	_, ok := pt.(*PredicatePathToken)
+	return ok && common.UtilsSliceContains(ctx.Options(), common.OPTION_FILTER_OBJECT_MEMBERS)
 }
 
 func createFilterPathTokenPredicate(target Token, ctx *EvaluationContextImpl) *filterPathTokenPredicate {
@@ -783,7 +834,7 @@ func createFilterPathTokenPredicate(target Token, ctx *EvaluationContextImpl) *f
 type wildCardPathTokenPredicate struct {
 }
 
-func (*wildCardPathTokenPredicate) matches(model interface{}) (bool, error) {
+func (*wildCardPathTokenPredicate) matches(currentPath string, model interface{}) (bool, error) {
 	return true, nil
 }
 
@@ -791,7 +842,7 @@ type arrayPathTokenPredicate struct {
 	ctx *EvaluationContextImpl
 }
 
-func (a *arrayPathTokenPredicate) matches(model interface{}) (bool, error) {
+func (a *arrayPathTokenPredicate) matches(currentPath string, model interface{}) (bool, error) {
 	return a.ctx.JsonProvider().IsArray(model), nil
 }
 
@@ -800,7 +851,7 @@ type propertyPathTokenPredicate struct {
 	propertyPathToken *PropertyPathToken
 }
 
-func (p *propertyPathTokenPredicate) matches(model interface{}) (bool, error) {
+func (p *propertyPathTokenPredicate) matches(currentPath string, model interface{}) (bool, error) {
 	if !p.ctx.JsonProvider().IsMap(model) {
 		return false, nil
 	}
@@ -847,95 +898,142 @@ func (s *ScanPathToken) GetTokenCount() (int, error) {
 }
 
 func (s *ScanPathToken) Evaluate(currentPath string, parent common.PathRef, model interface{}, ctx *EvaluationContextImpl) error {
-	pt, err := s.nextToken()
+	ctx.trace(common.TRACE_TOKEN_ENTERED, s, currentPath, model, false)
+	target, err := s.scanTarget(ctx)
 	if err != nil {
 		return err
 	}
-	return s.walk(pt, currentPath, parent, model, ctx, s.createScanPredicate(pt, ctx))
+	scanWalk([]*scanTarget{target}, currentPath, parent, model, ctx.JsonProvider())
+	return target.err
 }
 
-func (s *ScanPathToken) walk(pt Token, currentPath string, parent common.PathRef, model interface{}, ctx *EvaluationContextImpl, predicate ScanPredicate) error {
-	if ctx.JsonProvider().IsMap(model) {
-		return s.walkObject(pt, currentPath, parent, model, ctx, predicate)
-	} else if ctx.JsonProvider().IsArray(model) {
-		return s.walkArray(pt, currentPath, parent, model, ctx, predicate)
+func (s *ScanPathToken) scanTarget(ctx *EvaluationContextImpl) (*scanTarget, error) {
+	pt, err := s.nextToken()
+	if err != nil {
+		return nil, err
 	}
-	return nil
+	return &scanTarget{token: pt, predicate: s.createScanPredicate(pt, ctx), ctx: ctx}, nil
 }
 
-func (s *ScanPathToken) walkObject(pt Token, currentPath string, parent common.PathRef, model interface{}, ctx *EvaluationContextImpl, predicate ScanPredicate) error {
-	matchesResult, err := predicate.matches(model)
-	if err != nil {
-		return err
+// scanTarget is the token after a scan, evaluated at every node the scan walks to. Several targets share one walk of
+// the document, a target that fails keeps its error and is not evaluated anymore.
+type scanTarget struct {
+	token     Token
+	predicate ScanPredicate
+	ctx       *EvaluationContextImpl
+	err       error
+}
+
+func scanTargetsLive(targets []*scanTarget) bool {
+	for _, target := range targets {
+		if target.err == nil {
+			return true
+		}
 	}
-	if matchesResult {
-		err := pt.Evaluate(currentPath, parent, model, ctx)
-		if err != nil {
-			return err
+	return false
+}
+
+func scanTargetsFail(targets []*scanTarget, err error) {
+	for _, target := range targets {
+		if target.err == nil {
+			target.err = err
 		}
 	}
-	properties, err := ctx.JsonProvider().GetPropertyKeys(model)
+}
+
+// scanTargetsEnter enters a step in the contexts of the targets, each target has its own context
+func scanTargetsEnter(targets []*scanTarget, path string, parent string, key interface{}, value interface{}) {
+	for _, target := range targets {
+		target.ctx.enterStep(path, parent, key, value)
+	}
+}
+
+func scanTargetsLeave(targets []*scanTarget) {
+	for _, target := range targets {
+		target.ctx.leaveStep()
+	}
+}
+
+func scanWalk(targets []*scanTarget, currentPath string, parent common.PathRef, model interface{}, provider common.JsonProvider) {
+	if !scanTargetsLive(targets) {
+		return
+	}
+	if provider.IsMap(model) {
+		scanWalkObject(targets, currentPath, parent, model, provider)
+	} else if provider.IsArray(model) {
+		scanWalkArray(targets, currentPath, parent, model, provider)
+	}
+}
+
+func scanWalkObject(targets []*scanTarget, currentPath string, parent common.PathRef, model interface{}, provider common.JsonProvider) {
+	for _, target := range targets {
+		if target.err != nil {
+			continue
+		}
+		matchesResult, err := target.predicate.matches(currentPath, model)
+		if err == nil && matchesResult {
+			err = target.token.Evaluate(currentPath, parent, model, target.ctx)
+		}
+		target.err = err
+	}
+	properties, err := provider.GetPropertyKeys(model)
 	if err != nil {
-		return err
+		scanTargetsFail(targets, err)
+		return
 	}
 	for _, property := range properties {
 		evalPath := currentPath + "['" + property + "']"
-		propertyModel := ctx.JsonProvider().GetMapValue(model, property)
+		propertyModel := provider.GetMapValue(model, property)
 		if propertyModel != common.JsonProviderUndefined {
-			err := s.walk(pt, evalPath, CreateObjectPropertyPathRef(model, property), propertyModel, ctx, predicate)
-			if err != nil {
-				return err
-			}
+			scanTargetsEnter(targets, evalPath, currentPath, property, propertyModel)
+			scanWalk(targets, evalPath, CreateObjectPropertyPathRef(model, property), propertyModel, provider)
+			scanTargetsLeave(targets)
 		}
 	}
-	return nil
 }
 
-func (s *ScanPathToken) walkArray(pt Token, currentPath string, parent common.PathRef, model interface{}, ctx *EvaluationContextImpl, predicate ScanPredicate) error {
-	matchesResult, err := predicate.matches(model)
+func scanWalkArray(targets []*scanTarget, currentPath string, parent common.PathRef, model interface{}, provider common.JsonProvider) {
+	models, err := provider.ToArray(model)
 	if err != nil {
-		return err
+		scanTargetsFail(targets, err)
+		return
 	}
-	if matchesResult {
-		if pt.isLeaf() {
-			err = pt.Evaluate(currentPath, parent, model, ctx)
-			if err != nil {
-				return err
-			}
-		} else {
-			next, err := pt.nextToken()
-			if err != nil {
-				return err
-			}
-			models, err := ctx.JsonProvider().ToArray(model)
-			if err != nil {
-				return err
-			}
-			idx := 0
-			for _, evalModel := range models {
-				evalPath := currentPath + "[" + strconv.Itoa(idx) + "]"
-				next.SetUpstreamArrayIndex(idx)
-				err = next.Evaluate(evalPath, parent, evalModel, ctx)
-				if err != nil {
-					return err
-				}
-				idx++
-			}
+	for _, target := range targets {
+		if target.err != nil {
+			continue
 		}
+		target.err = target.evaluateArray(currentPath, parent, model, models)
+	}
+	for idx, evalModel := range models {
+		evalPath := currentPath + "[" + strconv.Itoa(idx) + "]"
+		scanTargetsEnter(targets, evalPath, currentPath, idx, evalModel)
+		scanWalk(targets, evalPath, CreateArrayIndexPathRef(model, idx), evalModel, provider)
+		scanTargetsLeave(targets)
 	}
+}
 
-	models, err := ctx.JsonProvider().ToArray(model)
+func (t *scanTarget) evaluateArray(currentPath string, parent common.PathRef, model interface{}, models []interface{}) error {
+	matchesResult, err := t.predicate.matches(currentPath, model)
+	if err != nil || !matchesResult {
+		return err
+	}
+	pt := t.token
+	if pt.isLeaf() || isMemberFilter(pt, t.ctx) {
+		return pt.Evaluate(currentPath, parent, model, t.ctx)
+	}
+	next, err := pt.nextToken()
 	if err != nil {
 		return err
 	}
-	idx := 0
-	for _, evalModel := range models {
+	for idx, evalModel := range models {
 		evalPath := currentPath + "[" + strconv.Itoa(idx) + "]"
-		err = s.walk(pt, evalPath, CreateArrayIndexPathRef(model, idx), evalModel, ctx, predicate)
+		next.SetUpstreamArrayIndex(idx)
+		t.ctx.enterStep(evalPath, currentPath, idx, evalModel)
+		err = next.Evaluate(evalPath, parent, evalModel, t.ctx)
+		t.ctx.leaveStep()
 		if err != nil {
 			return err
 		}
-		idx++
 	}
 	return nil
 }
@@ -996,7 +1094,7 @@ func (a *ArrayIndexPathToken) checkArrayModel(currentPath string, model interfac
 		if !a.IsUpstreamDefinite() || common.UtilsSliceContains(ctx.Options(), common.OPTION_SUPPRESS_EXCEPTIONS) {
 			return false, nil
 		} else {
-			return false, &common.PathNotFoundError{Message: "The path " + currentPath + " is null"}
+			return false, &common.PathNotFoundError{Message: "The path " + currentPath + " is null", ErrorDetails: common.ErrorDetails{Location: currentPath, ErrorCode: common.CodeNotAnArray}}
 		}
 	}
 
@@ -1004,13 +1102,14 @@ func (a *ArrayIndexPathToken) checkArrayModel(currentPath string, model interfac
 		if !a.IsUpstreamDefinite() || common.UtilsSliceContains(ctx.Options(), common.OPTION_SUPPRESS_EXCEPTIONS) {
 			return false, nil
 		} else {
-			return false, &common.PathNotFoundError{Message: fmt.Sprintf("Filter: %s can only be applied to arrays. Current context is: %s", a, model)}
+			return false, &common.PathNotFoundError{Message: fmt.Sprintf("Filter: %s can only be applied to arrays. Current context is: %s", a, model), ErrorDetails: common.ErrorDetails{Location: currentPath, ErrorCode: common.CodeNotAnArray}}
 		}
 	}
 	return true, nil
 }
 
 func (a *ArrayIndexPathToken) Evaluate(currentPath string, parent common.PathRef, model interface{}, ctx *EvaluationContextImpl) error {
+	ctx.trace(common.TRACE_TOKEN_ENTERED, a, currentPath, model, false)
 	checkResult, err := a.checkArrayModel(currentPath, model, ctx)
 	if err != nil {
 		return err
@@ -1037,6 +1136,10 @@ func (a *ArrayIndexPathToken) GetPathFragment() string {
 	return a.arrayIndexOperation.String()
 }
 
+func (a *ArrayIndexPathToken) GetArrayIndexOperation() *ArrayIndexOperation {
+	return a.arrayIndexOperation
+}
+
 func (a *ArrayIndexPathToken) IsTokenDefinite() bool {
 	return a.arrayIndexOperation.IsSingleIndexOperation()
 }
@@ -1072,7 +1175,7 @@ func (a *ArraySlicePathToken) checkArrayModel(currentPath string, model interfac
 		if !a.IsUpstreamDefinite() || common.UtilsSliceContains(ctx.Options(), common.OPTION_SUPPRESS_EXCEPTIONS) {
 			return false, nil
 		} else {
-			return false, &common.PathNotFoundError{Message: "The path " + currentPath + " is null"}
+			return false, &common.PathNotFoundError{Message: "The path " + currentPath + " is null", ErrorDetails: common.ErrorDetails{Location: currentPath, ErrorCode: common.CodeNotAnArray}}
 		}
 	}
 
@@ -1080,13 +1183,14 @@ func (a *ArraySlicePathToken) checkArrayModel(currentPath string, model interfac
 		if !a.IsUpstreamDefinite() || common.UtilsSliceContains(ctx.Options(), common.OPTION_SUPPRESS_EXCEPTIONS) {
 			return false, nil
 		} else {
-			return false, &common.PathNotFoundError{Message: fmt.Sprintf("Filter: %s can only be applied to arrays. Current context is: %s", a, model)}
+			return false, &common.PathNotFoundError{Message: fmt.Sprintf("Filter: %s can only be applied to arrays. Current context is: %s", a, model), ErrorDetails: common.ErrorDetails{Location: currentPath, ErrorCode: common.CodeNotAnArray}}
 		}
 	}
 	return true, nil
 }
 
 func (a *ArraySlicePathToken) Evaluate(currentPath string, parent common.PathRef, model interface{}, ctx *EvaluationContextImpl) error {
+	ctx.trace(common.TRACE_TOKEN_ENTERED, a, currentPath, model, false)
 	checkPass, err := a.checkArrayModel(currentPath, model, ctx)
 	if err != nil {
 		return err
@@ -1117,8 +1221,6 @@ func (a *ArraySlicePathToken) sliceFrom(currentPath string, parent common.PathRe
 	}
 	from = common.UtilsMaxInt(0, from)
 
-	log.Printf("Slice from index on array with length: %d. From index: %d to: %d. Input: %s", length, from, length-1, common.UtilsToString(a))
-
 	if length == 0 || from >= length {
 		return nil
 	}
@@ -1140,13 +1242,13 @@ func (a *ArraySlicePathToken) sliceBetween(currentPath string, parent common.Pat
 	to := a.operation.To()
 
 	to = common.UtilsMinInt(length, to)
+	// indexes before -length are outside of the array
+	from = common.UtilsMaxInt(from, -length)
 
 	if from >= to || length == 0 {
 		return nil
 	}
 
-	log.Printf("Slice between indexes on array with length: %d. From index: %d to: %d. Input: %s", length, from, to, common.UtilsToString(a))
-
 	for i := from; i < to; i++ {
 		err = a.handleArrayIndex(i, currentPath, model, ctx)
 		if err != nil {
@@ -1172,8 +1274,6 @@ func (a *ArraySlicePathToken) sliceTo(currentPath string, parent common.PathRef,
 	}
 	to = common.UtilsMinInt(length, to)
 
-	log.Printf("Slice to index on array with length: %d. From index: 0 to: %d. Input: %s", length, to, common.UtilsToString(a))
-
 	for i := 0; i < to; i++ {
 		err = a.handleArrayIndex(i, currentPath, model, ctx)
 		if err != nil {
@@ -1198,6 +1298,10 @@ func (*ArraySlicePathToken) IsTokenDefinite() bool {
 	return false
 }
 
+func (a *ArraySlicePathToken) GetOperation() *ArraySliceOperation {
+	return a.operation
+}
+
 func CreateArraySlicePathToken(operation *ArraySliceOperation) *ArraySlicePathToken {
 	return &ArraySlicePathToken{
 		defaultToken: &defaultToken{upstreamArrayIndex: -1},
@@ -1229,8 +1333,11 @@ func (p *PredicatePathToken) GetTokenCount() (int, error) {
 }
 
 func (p *PredicatePathToken) Evaluate(currentPath string, ref common.PathRef, model interface{}, ctx *EvaluationContextImpl) error {
-	if ctx.JsonProvider().IsMap(model) {
-		acceptResult, err := p.accept(model, ctx.RootDocument(), ctx.Configuration(), ctx)
+	ctx.trace(common.TRACE_TOKEN_ENTERED, p, currentPath, model, false)
+	if ctx.JsonProvider().IsMap(model) && isMemberFilter(p, ctx) {
+		return p.evaluateMembers(currentPath, model, ctx)
+	} else if ctx.JsonProvider().IsMap(model) {
+		acceptResult, err := p.accept(model, ctx.RootDocument(), ctx.Configuration(), ctx, createItemLocation(ctx, currentPath))
 		if err != nil {
 			return err
 		}
@@ -1260,7 +1367,7 @@ func (p *PredicatePathToken) Evaluate(currentPath string, ref common.PathRef, mo
 			return err
 		}
 		for _, idxModel := range objects {
-			acceptResult, err := p.accept(idxModel, ctx.RootDocument(), ctx.Configuration(), ctx)
+			acceptResult, err := p.accept(idxModel, ctx.RootDocument(), ctx.Configuration(), ctx, createElementLocation(ctx, currentPath, model, idx))
 			if err != nil {
 				return err
 			}
@@ -1280,14 +1387,39 @@ func (p *PredicatePathToken) Evaluate(currentPath string, ref common.PathRef, mo
 	return nil
 }
 
-func (p *PredicatePathToken) accept(obj interface{}, root interface{}, configuration *common.Configuration, evaluationContext *EvaluationContextImpl) (bool, error) {
-	ctx := common.CreatePredicateContextImpl(obj, root, configuration, evaluationContext.DocumentEvalCache())
+func (p *PredicatePathToken) evaluateMembers(currentPath string, model interface{}, ctx *EvaluationContextImpl) error {
+	keys, err := ctx.JsonProvider().GetPropertyKeys(model)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		member := ctx.JsonProvider().GetMapValue(model, key)
+		acceptResult, err := p.accept(member, ctx.RootDocument(), ctx.Configuration(), ctx, createMemberLocation(ctx, currentPath, model, key))
+		if err != nil {
+			return err
+		}
+		if acceptResult {
+			if err = tokenHandleObjectProperty(p, currentPath, model, ctx, []string{key}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (p *PredicatePathToken) accept(obj interface{}, root interface{}, configuration *common.Configuration, evaluationContext *EvaluationContextImpl, location common.ItemLocation) (bool, error) {
+	if configuration.Tracer() != nil {
+		// the paths read by the predicates are not part of the traced evaluation
+		configuration = configuration.SetTracer(nil)
+	}
+	ctx := common.CreatePredicateContextImplByLocation(obj, root, configuration, evaluationContext.DocumentEvalCache(), location)
 
 	for _, predicate := range p.predicates {
 		pResult, err := predicate.Apply(ctx)
 		if err != nil {
 			return false, err
 		}
+		evaluationContext.tracePredicate(p, predicate, ctx, location, pResult)
 		if !pResult {
 			return false, nil
 		}
@@ -1312,6 +1444,137 @@ func (p *PredicatePathToken) IsTokenDefinite() bool {
 	return false
 }
 
+func (p *PredicatePathToken) GetPredicates() []common.Predicate {
+	return p.predicates
+}
+
 func CreatePredicatePathToken(predicates []common.Predicate) *PredicatePathToken {
 	return &PredicatePathToken{defaultToken: &defaultToken{upstreamArrayIndex: -1}, predicates: predicates}
 }
+
+// ParentPathToken -----
+
+type ParentPathToken struct {
+	*defaultToken
+}
+
+type parentVisit struct {
+	token *ParentPathToken
+	path  string
+	depth int
+}
+
+func (p *ParentPathToken) String() string {
+	return tokenString(p)
+}
+
+func (p *ParentPathToken) IsPathDefinite() bool {
+	return tokenIsPathDefinite(p)
+}
+
+func (p *ParentPathToken) appendTailToken(next Token) Token {
+	return tokenAppendTailToken(p, next)
+}
+
+func (p *ParentPathToken) GetTokenCount() (int, error) {
+	return tokenGetTokenCount(p)
+}
+
+func (p *ParentPathToken) Evaluate(currentPath string, parent common.PathRef, model interface{}, ctx *EvaluationContextImpl) error {
+	ctx.trace(common.TRACE_TOKEN_ENTERED, p, currentPath, model, false)
+	parentStep, ok := ctx.parentStep(currentPath)
+	if !ok {
+		// the root has no parent
+		return nil
+	}
+	parentPath := parentStep.path
+
+	// siblings share their parent, it is only selected once
+	if ctx.visitedParents == nil {
+		ctx.visitedParents = map[parentVisit]bool{}
+	}
+	visit := parentVisit{token: p, path: parentPath, depth: parentStep.depth}
+	if ctx.visitedParents[visit] {
+		return nil
+	}
+	ctx.visitedParents[visit] = true
+
+	ref := ctx.pathRefOf(parentStep)
+	parentModel := parentStep.value
+	if p.isLeaf() {
+		return ctx.AddResult(parentPath, ref, parentModel)
+	}
+	next, err := p.nextToken()
+	if err != nil {
+		return err
+	}
+	return next.Evaluate(parentPath, ref, parentModel, ctx)
+}
+
+func (p *ParentPathToken) GetPathFragment() string {
+	return "^"
+}
+
+func (p *ParentPathToken) IsTokenDefinite() bool {
+	return true
+}
+
+func CreateParentPathToken() *ParentPathToken {
+	return &ParentPathToken{defaultToken: &defaultToken{upstreamArrayIndex: -1}}
+}
+
+// PropertyNamePathToken -----
+
+// PropertyNamePathToken selects the property name or the array index of the current node instead of its value. Selecting
+// the names of the members of an object that pass a filter, like $.env[?(@property =~ /^FEATURE_/)]~, needs
+// OPTION_FILTER_OBJECT_MEMBERS, filters test the object itself otherwise.
+type PropertyNamePathToken struct {
+	*defaultToken
+}
+
+func (p *PropertyNamePathToken) String() string {
+	return tokenString(p)
+}
+
+func (p *PropertyNamePathToken) IsPathDefinite() bool {
+	return tokenIsPathDefinite(p)
+}
+
+func (p *PropertyNamePathToken) appendTailToken(next Token) Token {
+	return tokenAppendTailToken(p, next)
+}
+
+func (p *PropertyNamePathToken) GetTokenCount() (int, error) {
+	return tokenGetTokenCount(p)
+}
+
+func (p *PropertyNamePathToken) Evaluate(currentPath string, parent common.PathRef, model interface{}, ctx *EvaluationContextImpl) error {
+	ctx.trace(common.TRACE_TOKEN_ENTERED, p, currentPath, model, false)
+	if _, ok := ctx.parentStep(currentPath); !ok {
+		// the root has no name
+		return nil
+	}
+	step, _ := ctx.step(currentPath)
+	evalPath := currentPath + "~"
+	name := step.key
+	if p.isLeaf() {
+		return ctx.AddResult(evalPath, PathRefNoOp, name)
+	}
+	next, err := p.nextToken()
+	if err != nil {
+		return err
+	}
+	return next.Evaluate(evalPath, PathRefNoOp, name, ctx)
+}
+
+func (p *PropertyNamePathToken) GetPathFragment() string {
+	return "~"
+}
+
+func (p *PropertyNamePathToken) IsTokenDefinite() bool {
+	return true
+}
+
+func CreatePropertyNamePathToken() *PropertyNamePathToken {
+	return &PropertyNamePathToken{defaultToken: &defaultToken{upstreamArrayIndex: -1}}
+}
@@ -0,0 +1,262 @@
+package path
+
+import (
+	"github.com/CuiChao512/go-jsonpath/jsonpath/common"
+	"github.com/CuiChao512/go-jsonpath/jsonpath/function"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// valueFunction is embedded by functions that compute their result in Invoke instead of aggregating values.
+type valueFunction struct {
+}
+
+func (*valueFunction) Next(value interface{}) {}
+
+func (*valueFunction) GetValue() interface{} { return nil }
+
+func stringModel(name string, model interface{}) (string, error) {
+	if str, ok := model.(string); ok {
+		return str, nil
+	}
+	return "", &common.JsonPathError{Message: "Function " + name + "() expects a string, got: " + common.UtilsToString(model)}
+}
+
+func parameterValue(name string, parameters []*function.Parameter, index int) (interface{}, error) {
+	if index >= len(parameters) {
+		return nil, &common.InvalidPathError{Message: "Function " + name + "() is missing parameter " + common.UtilsToString(index+1)}
+	}
+	return parameters[index].GetValue()
+}
+
+func stringParameter(name string, parameters []*function.Parameter, index int) (string, error) {
+	value, err := parameterValue(name, parameters, index)
+	if err != nil {
+		return "", err
+	}
+	if str, ok := value.(string); ok {
+		return str, nil
+	}
+	return "", &common.InvalidPathError{Message: "Function " + name + "() expects a string as parameter " + common.UtilsToString(index+1)}
+}
+
+func intParameter(name string, parameters []*function.Parameter, index int) (int, error) {
+	value, err := parameterValue(name, parameters, index)
+	if err != nil {
+		return 0, err
+	}
+	number, err := common.UtilsNumberToFloat64(value)
+	if err != nil || number != float64(int(number)) {
+		return 0, &common.InvalidPathError{Message: "Function " + name + "() expects an integer as parameter " + common.UtilsToString(index+1)}
+	}
+	return int(number), nil
+}
+
+// Lower function
+type Lower struct {
+	*valueFunction
+}
+
+func (*Lower) Invoke(nextAndGet PathFunctionNextAndGet, currentPath string, parent common.PathRef, model interface{}, ctx common.EvaluationContext, parameters []*function.Parameter) (interface{}, error) {
+	str, err := stringModel("lower", model)
+	if err != nil {
+		return nil, err
+	}
+	return strings.ToLower(str), nil
+}
+
+// Upper function
+type Upper struct {
+	*valueFunction
+}
+
+func (*Upper) Invoke(nextAndGet PathFunctionNextAndGet, currentPath string, parent common.PathRef, model interface{}, ctx common.EvaluationContext, parameters []*function.Parameter) (interface{}, error) {
+	str, err := stringModel("upper", model)
+	if err != nil {
+		return nil, err
+	}
+	return strings.ToUpper(str), nil
+}
+
+// Trim function
+type Trim struct {
+	*valueFunction
+}
+
+func (*Trim) Invoke(nextAndGet PathFunctionNextAndGet, currentPath string, parent common.PathRef, model interface{}, ctx common.EvaluationContext, parameters []*function.Parameter) (interface{}, error) {
+	str, err := stringModel("trim", model)
+	if err != nil {
+		return nil, err
+	}
+	return strings.TrimSpace(str), nil
+}
+
+// Substring function, substring(start) or substring(start, length). Positions count characters, a negative start
+// counts from the end of the string.
+type Substring struct {
+	*valueFunction
+}
+
+func (*Substring) Invoke(nextAndGet PathFunctionNextAndGet, currentPath string, parent common.PathRef, model interface{}, ctx common.EvaluationContext, parameters []*function.Parameter) (interface{}, error) {
+	str, err := stringModel("substring", model)
+	if err != nil {
+		return nil, err
+	}
+	runes := []rune(str)
+	start, err := intParameter("substring", parameters, 0)
+	if err != nil {
+		return nil, err
+	}
+	if start < 0 {
+		start += len(runes)
+	}
+	if start < 0 {
+		start = 0
+	} else if start > len(runes) {
+		start = len(runes)
+	}
+	end := len(runes)
+	if len(parameters) > 1 {
+		length, err := intParameter("substring", parameters, 1)
+		if err != nil {
+			return nil, err
+		}
+		if length < 0 {
+			return nil, &common.InvalidPathError{Message: "Function substring() expects a length that is not negative"}
+		}
+		if start+length < end {
+			end = start + length
+		}
+	}
+	return string(runes[start:end]), nil
+}
+
+// Replace function, replace(pattern, replacement) replaces all matches of a regular expression. The replacement can
+// refer to groups with $1.
+type Replace struct {
+	*valueFunction
+}
+
+func (*Replace) Invoke(nextAndGet PathFunctionNextAndGet, currentPath string, parent common.PathRef, model interface{}, ctx common.EvaluationContext, parameters []*function.Parameter) (interface{}, error) {
+	str, err := stringModel("replace", model)
+	if err != nil {
+		return nil, err
+	}
+	pattern, err := stringParameter("replace", parameters, 0)
+	if err != nil {
+		return nil, err
+	}
+	replacement, err := stringParameter("replace", parameters, 1)
+	if err != nil {
+		return nil, err
+	}
+	compiledPattern, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, &common.InvalidPathError{Message: "Function replace() got an invalid pattern: " + err.Error(), ErrorDetails: common.ErrorDetails{ErrorCode: common.CodeInvalidPattern, Cause: err}}
+	}
+	return compiledPattern.ReplaceAllString(str, replacement), nil
+}
+
+// Split function
+type Split struct {
+	*valueFunction
+}
+
+func (*Split) Invoke(nextAndGet PathFunctionNextAndGet, currentPath string, parent common.PathRef, model interface{}, ctx common.EvaluationContext, parameters []*function.Parameter) (interface{}, error) {
+	str, err := stringModel("split", model)
+	if err != nil {
+		return nil, err
+	}
+	separator, err := stringParameter("split", parameters, 0)
+	if err != nil {
+		return nil, err
+	}
+	result := ctx.Configuration().JsonProvider().CreateArray()
+	for _, part := range strings.Split(str, separator) {
+		result = append(result, part)
+	}
+	return result, nil
+}
+
+// Join function, join(separator) joins the items of an array. The separator defaults to ",".
+type Join struct {
+	*valueFunction
+}
+
+func (*Join) Invoke(nextAndGet PathFunctionNextAndGet, currentPath string, parent common.PathRef, model interface{}, ctx common.EvaluationContext, parameters []*function.Parameter) (interface{}, error) {
+	if !ctx.Configuration().JsonProvider().IsArray(model) {
+		return nil, &common.JsonPathError{Message: "Function join() expects an array, got: " + common.UtilsToString(model)}
+	}
+	separator := ","
+	if len(parameters) > 0 {
+		var err error
+		if separator, err = stringParameter("join", parameters, 0); err != nil {
+			return nil, err
+		}
+	}
+	objects, err := ctx.Configuration().JsonProvider().ToArray(model)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]string, 0, len(objects))
+	for _, obj := range objects {
+		items = append(items, common.UtilsToString(obj))
+	}
+	return strings.Join(items, separator), nil
+}
+
+// StartsWith function
+type StartsWith struct {
+	*valueFunction
+}
+
+func (*StartsWith) Invoke(nextAndGet PathFunctionNextAndGet, currentPath string, parent common.PathRef, model interface{}, ctx common.EvaluationContext, parameters []*function.Parameter) (interface{}, error) {
+	str, err := stringModel("startsWith", model)
+	if err != nil {
+		return nil, err
+	}
+	prefix, err := stringParameter("startsWith", parameters, 0)
+	if err != nil {
+		return nil, err
+	}
+	return strings.HasPrefix(str, prefix), nil
+}
+
+// EndsWith function
+type EndsWith struct {
+	*valueFunction
+}
+
+func (*EndsWith) Invoke(nextAndGet PathFunctionNextAndGet, currentPath string, parent common.PathRef, model interface{}, ctx common.EvaluationContext, parameters []*function.Parameter) (interface{}, error) {
+	str, err := stringModel("endsWith", model)
+	if err != nil {
+		return nil, err
+	}
+	suffix, err := stringParameter("endsWith", parameters, 0)
+	if err != nil {
+		return nil, err
+	}
+	return strings.HasSuffix(str, suffix), nil
+}
+
+// IndexOf function, the character position of the first occurrence of a string, or -1.
+type IndexOf struct {
+	*valueFunction
+}
+
+func (*IndexOf) Invoke(nextAndGet PathFunctionNextAndGet, currentPath string, parent common.PathRef, model interface{}, ctx common.EvaluationContext, parameters []*function.Parameter) (interface{}, error) {
+	str, err := stringModel("indexOf", model)
+	if err != nil {
+		return nil, err
+	}
+	search, err := stringParameter("indexOf", parameters, 0)
+	if err != nil {
+		return nil, err
+	}
+	index := strings.Index(str, search)
+	if index < 0 {
+		return -1, nil
+	}
+	return utf8.RuneCountInString(str[:index]), nil
+}
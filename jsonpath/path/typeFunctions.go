@@ -0,0 +1,111 @@
+package path
+
+import (
+	"github.com/CuiChao512/go-jsonpath/jsonpath/common"
+	"github.com/CuiChao512/go-jsonpath/jsonpath/function"
+	"strconv"
+	"strings"
+)
+
+// jsonTypeName is the JSON type of a value: number, string, boolean, null, array or object
+func jsonTypeName(value interface{}, ctx common.EvaluationContext) string {
+	jsonProvider := ctx.Configuration().JsonProvider()
+	switch value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	}
+	switch {
+	case common.UtilsIsNumber(value):
+		return "number"
+	case jsonProvider.IsArray(value):
+		return "array"
+	case jsonProvider.IsMap(value):
+		return "object"
+	default:
+		return common.UtilsGetTypeName(value)
+	}
+}
+
+// ToNumber function, converts numbers and strings holding a number
+type ToNumber struct {
+	*valueFunction
+}
+
+func (*ToNumber) Invoke(nextAndGet PathFunctionNextAndGet, currentPath string, parent common.PathRef, model interface{}, ctx common.EvaluationContext, parameters []*function.Parameter) (interface{}, error) {
+	if number, ok := common.UtilsCoerceNumber(model); ok {
+		return number, nil
+	}
+	return nil, &common.JsonPathError{Message: "Function toNumber() can not convert: " + common.UtilsToString(model)}
+}
+
+// ToString function, strings stay as they are and other values become their JSON text
+type ToString struct {
+	*valueFunction
+}
+
+func (*ToString) Invoke(nextAndGet PathFunctionNextAndGet, currentPath string, parent common.PathRef, model interface{}, ctx common.EvaluationContext, parameters []*function.Parameter) (interface{}, error) {
+	return textOf(model, ctx)
+}
+
+func textOf(model interface{}, ctx common.EvaluationContext) (string, error) {
+	switch value := model.(type) {
+	case string:
+		return value, nil
+	case nil:
+		return "null", nil
+	case bool:
+		return strconv.FormatBool(value), nil
+	}
+	if common.UtilsIsNumber(model) {
+		return common.UtilsToString(model), nil
+	}
+	return ctx.Configuration().JsonProvider().ToJson(model)
+}
+
+// ToBoolean function, converts booleans, strings like "true" or "false" and numbers, which are true unless zero
+type ToBoolean struct {
+	*valueFunction
+}
+
+func (*ToBoolean) Invoke(nextAndGet PathFunctionNextAndGet, currentPath string, parent common.PathRef, model interface{}, ctx common.EvaluationContext, parameters []*function.Parameter) (interface{}, error) {
+	switch value := model.(type) {
+	case bool:
+		return value, nil
+	case string:
+		if b, err := strconv.ParseBool(strings.TrimSpace(value)); err == nil {
+			return b, nil
+		}
+	default:
+		if number, err := common.UtilsNumberToFloat64(model); err == nil {
+			return number != 0, nil
+		}
+	}
+	return nil, &common.JsonPathError{Message: "Function toBoolean() can not convert: " + common.UtilsToString(model)}
+}
+
+// Type function, the JSON type of a value
+type Type struct {
+	*valueFunction
+}
+
+func (*Type) Invoke(nextAndGet PathFunctionNextAndGet, currentPath string, parent common.PathRef, model interface{}, ctx common.EvaluationContext, parameters []*function.Parameter) (interface{}, error) {
+	return jsonTypeName(model, ctx), nil
+}
+
+// IsType function, isNumber(), isString(), isArray() and isObject() test the JSON type of a value
+type IsType struct {
+	*valueFunction
+	typeName string
+}
+
+func (i *IsType) Invoke(nextAndGet PathFunctionNextAndGet, currentPath string, parent common.PathRef, model interface{}, ctx common.EvaluationContext, parameters []*function.Parameter) (interface{}, error) {
+	return jsonTypeName(model, ctx) == i.typeName, nil
+}
+
+func CreateIsTypeFunction(typeName string) *IsType {
+	return &IsType{typeName: typeName}
+}
@@ -0,0 +1,65 @@
+package jsonpath
+
+import (
+	"errors"
+	"github.com/CuiChao512/go-jsonpath/jsonpath/common"
+	"github.com/CuiChao512/go-jsonpath/jsonpath/path"
+)
+
+// PathSet reads many paths from a document in one traversal. The paths share the reads of their common property
+// prefixes and deep scans, $.event.user.id and $.event.user.name read $.event.user once.
+type PathSet struct {
+	paths     []string
+	jsonpaths []*Jsonpath
+	compiled  *path.CompiledPathSet
+}
+
+// CompilePathSet compiles the paths of a set with the Jayway grammar, a set can not be read in RFC 9535 mode
+func CompilePathSet(paths ...string) (*PathSet, error) {
+	set := &PathSet{paths: append([]string(nil), paths...)}
+	compiledPaths := make([]*path.CompiledPath, 0, len(paths))
+	for _, pathString := range paths {
+		jsonpath, err := compileJsonpath(pathString)
+		if err != nil {
+			return nil, err
+		}
+		compiledPath, ok := jsonpath.path.(*path.CompiledPath)
+		if !ok {
+			return nil, errors.New("path can not cast to *CompiledPath")
+		}
+		set.jsonpaths = append(set.jsonpaths, jsonpath)
+		compiledPaths = append(compiledPaths, compiledPath)
+	}
+	set.compiled = path.CreateCompiledPathSet(compiledPaths)
+	return set, nil
+}
+
+func (s *PathSet) Paths() []string {
+	return append([]string(nil), s.paths...)
+}
+
+// Read returns the results of the paths keyed by path, each result is the one Read returns for the path alone. If a
+// path fails, the results of the other paths are still returned with the error of the first failed path.
+func (s *PathSet) Read(jsonObject interface{}, config *common.Configuration) (map[string]interface{}, error) {
+	if common.UtilsSliceContains(config.Options(), common.OPTION_RFC9535) {
+		return nil, &common.InvalidPathError{Message: "Path sets are not supported in RFC 9535 mode"}
+	}
+	results := make(map[string]interface{}, len(s.paths))
+	contexts, errs := s.compiled.Evaluate(jsonObject, config)
+	var firstErr error
+	for i, jsonpath := range s.jsonpaths {
+		result, skip := jsonpath.skipEvaluation(config)
+		err := errs[i]
+		if !skip && err == nil {
+			result, err = jsonpath.readResult(contexts[i], config)
+		}
+		if err != nil {
+			if firstErr == nil {
+				firstErr = common.ErrorWithPath(err, jsonpath.path.String())
+			}
+			continue
+		}
+		results[s.paths[i]] = result
+	}
+	return results, firstErr
+}
@@ -0,0 +1,719 @@
+package rfc9535
+
+import (
+	"github.com/CuiChao512/go-jsonpath/jsonpath/common"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+const (
+	maxSafeInteger = 1<<53 - 1
+	minSafeInteger = -(1<<53 - 1)
+)
+
+type parser struct {
+	query    string
+	runes    []rune
+	position int
+}
+
+func createParser(query string) *parser {
+	return &parser{query: query, runes: []rune(query)}
+}
+
+func (p *parser) fail(message string) error {
+	return &common.InvalidPathError{Message: message + " at position " + strconv.Itoa(p.position) + " in query: " + p.query}
+}
+
+func (p *parser) inBounds() bool {
+	return p.position < len(p.runes)
+}
+
+func (p *parser) current() rune {
+	if p.inBounds() {
+		return p.runes[p.position]
+	}
+	return 0
+}
+
+func (p *parser) peek(offset int) rune {
+	if p.position+offset < len(p.runes) {
+		return p.runes[p.position+offset]
+	}
+	return 0
+}
+
+func (p *parser) hasPrefix(s string) bool {
+	for i, r := range []rune(s) {
+		if p.peek(i) != r {
+			return false
+		}
+	}
+	return true
+}
+
+func isBlank(r rune) bool {
+	return r == ' ' || r == '\t' || r == '\n' || r == '\r'
+}
+
+func (p *parser) skipBlanks() {
+	for p.inBounds() && isBlank(p.current()) {
+		p.position++
+	}
+}
+
+func (p *parser) read(r rune) error {
+	if p.current() != r || !p.inBounds() {
+		return p.fail("Expected '" + string(r) + "'")
+	}
+	p.position++
+	return nil
+}
+
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
+func isAlpha(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+}
+
+func isNameFirst(r rune) bool {
+	return isAlpha(r) || r == '_' || (r >= 0x80 && r <= 0xD7FF) || (r >= 0xE000 && r <= 0x10FFFF)
+}
+
+func isNameChar(r rune) bool {
+	return isNameFirst(r) || isDigit(r)
+}
+
+func (p *parser) parseQuery() ([]*segment, error) {
+	if err := p.read('$'); err != nil {
+		return nil, err
+	}
+	segments, err := p.parseSegments()
+	if err != nil {
+		return nil, err
+	}
+	if p.inBounds() {
+		return nil, p.fail("Unexpected character '" + string(p.current()) + "'")
+	}
+	return segments, nil
+}
+
+func (p *parser) parseSegments() ([]*segment, error) {
+	var segments []*segment
+	for {
+		savepoint := p.position
+		p.skipBlanks()
+		if p.current() != '.' && p.current() != '[' || !p.inBounds() {
+			p.position = savepoint
+			return segments, nil
+		}
+		s, err := p.parseSegment()
+		if err != nil {
+			return nil, err
+		}
+		segments = append(segments, s)
+	}
+}
+
+func (p *parser) parseSegment() (*segment, error) {
+	if p.current() == '[' {
+		selectors, err := p.parseBracketedSelection()
+		if err != nil {
+			return nil, err
+		}
+		return &segment{selectors: selectors}, nil
+	}
+	p.position++
+	descendant := false
+	if p.current() == '.' {
+		descendant = true
+		p.position++
+		if p.current() == '[' {
+			selectors, err := p.parseBracketedSelection()
+			if err != nil {
+				return nil, err
+			}
+			return &segment{descendant: true, selectors: selectors}, nil
+		}
+	}
+	if p.current() == '*' {
+		p.position++
+		return &segment{descendant: descendant, selectors: []selector{&wildcardSelector{}}}, nil
+	}
+	name, err := p.parseMemberNameShorthand()
+	if err != nil {
+		return nil, err
+	}
+	return &segment{descendant: descendant, selectors: []selector{&nameSelector{name: name}}}, nil
+}
+
+func (p *parser) parseMemberNameShorthand() (string, error) {
+	if !p.inBounds() || !isNameFirst(p.current()) {
+		return "", p.fail("Expected member name")
+	}
+	begin := p.position
+	for p.inBounds() && isNameChar(p.current()) {
+		p.position++
+	}
+	return string(p.runes[begin:p.position]), nil
+}
+
+func (p *parser) parseBracketedSelection() ([]selector, error) {
+	if err := p.read('['); err != nil {
+		return nil, err
+	}
+	var selectors []selector
+	for {
+		p.skipBlanks()
+		sel, err := p.parseSelector()
+		if err != nil {
+			return nil, err
+		}
+		selectors = append(selectors, sel)
+		p.skipBlanks()
+		if p.current() == ',' && p.inBounds() {
+			p.position++
+			continue
+		}
+		if err := p.read(']'); err != nil {
+			return nil, err
+		}
+		return selectors, nil
+	}
+}
+
+func (p *parser) parseSelector() (selector, error) {
+	switch c := p.current(); {
+	case !p.inBounds():
+		return nil, p.fail("Expected selector")
+	case c == '\'' || c == '"':
+		name, err := p.parseStringLiteral()
+		if err != nil {
+			return nil, err
+		}
+		return &nameSelector{name: name}, nil
+	case c == '*':
+		p.position++
+		return &wildcardSelector{}, nil
+	case c == '?':
+		p.position++
+		p.skipBlanks()
+		expression, err := p.parseLogicalOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := checkTestExpressions(p, expression); err != nil {
+			return nil, err
+		}
+		return &filterSelector{expression: expression}, nil
+	case c == ':' || c == '-' || isDigit(c):
+		return p.parseIndexOrSlice()
+	default:
+		return nil, p.fail("Unexpected character '" + string(c) + "'")
+	}
+}
+
+func (p *parser) parseIndexOrSlice() (selector, error) {
+	var bounds [3]*int
+	for i := 0; i < 3; i++ {
+		if i > 0 {
+			p.skipBlanks()
+			if p.current() != ':' {
+				if i == 1 {
+					return &indexSelector{index: *bounds[0]}, nil
+				}
+				break
+			}
+			p.position++
+			p.skipBlanks()
+		}
+		if p.current() == '-' || isDigit(p.current()) {
+			value, err := p.parseInt()
+			if err != nil {
+				return nil, err
+			}
+			bounds[i] = &value
+		} else if i == 0 && p.current() != ':' {
+			return nil, p.fail("Expected index or slice")
+		}
+	}
+	return &sliceSelector{start: bounds[0], end: bounds[1], step: bounds[2]}, nil
+}
+
+func (p *parser) parseInt() (int, error) {
+	begin := p.position
+	if p.current() == '-' {
+		p.position++
+	}
+	if !isDigit(p.current()) || !p.inBounds() {
+		return 0, p.fail("Expected integer")
+	}
+	if p.current() == '0' {
+		p.position++
+		if p.position-begin > 1 {
+			return 0, p.fail("Negative zero is not a valid integer")
+		}
+		if isDigit(p.current()) {
+			return 0, p.fail("Leading zeros are not allowed")
+		}
+		return 0, nil
+	}
+	for p.inBounds() && isDigit(p.current()) {
+		p.position++
+	}
+	value, err := strconv.ParseInt(string(p.runes[begin:p.position]), 10, 64)
+	if err != nil || value > maxSafeInteger || value < minSafeInteger {
+		return 0, p.fail("Integer out of range")
+	}
+	return int(value), nil
+}
+
+func (p *parser) parseStringLiteral() (string, error) {
+	quote := p.current()
+	p.position++
+	sb := &strings.Builder{}
+	for {
+		if !p.inBounds() {
+			return "", p.fail("String literal is not closed")
+		}
+		c := p.current()
+		p.position++
+		switch {
+		case c == quote:
+			return sb.String(), nil
+		case c < 0x20:
+			return "", p.fail("Control characters must be escaped in string literals")
+		case c == '\\':
+			r, err := p.parseEscape(quote)
+			if err != nil {
+				return "", err
+			}
+			sb.WriteRune(r)
+		default:
+			sb.WriteRune(c)
+		}
+	}
+}
+
+func (p *parser) parseEscape(quote rune) (rune, error) {
+	c := p.current()
+	if !p.inBounds() {
+		return 0, p.fail("Invalid escape sequence")
+	}
+	p.position++
+	switch c {
+	case 'b':
+		return '\b', nil
+	case 'f':
+		return '\f', nil
+	case 'n':
+		return '\n', nil
+	case 'r':
+		return '\r', nil
+	case 't':
+		return '\t', nil
+	case '/':
+		return '/', nil
+	case '\\':
+		return '\\', nil
+	case 'u':
+		high, err := p.parseHex4()
+		if err != nil {
+			return 0, err
+		}
+		if utf16.IsSurrogate(high) {
+			if high >= 0xDC00 || !p.hasPrefix("\\u") {
+				return 0, p.fail("Invalid surrogate pair")
+			}
+			p.position += 2
+			low, err := p.parseHex4()
+			if err != nil {
+				return 0, err
+			}
+			r := utf16.DecodeRune(high, low)
+			if r == 0xFFFD {
+				return 0, p.fail("Invalid surrogate pair")
+			}
+			return r, nil
+		}
+		return high, nil
+	default:
+		if c == quote {
+			return c, nil
+		}
+		return 0, p.fail("Invalid escape sequence")
+	}
+}
+
+func (p *parser) parseHex4() (rune, error) {
+	if p.position+4 > len(p.runes) {
+		return 0, p.fail("Invalid unicode escape")
+	}
+	value, err := strconv.ParseUint(string(p.runes[p.position:p.position+4]), 16, 32)
+	if err != nil {
+		return 0, p.fail("Invalid unicode escape")
+	}
+	p.position += 4
+	return rune(value), nil
+}
+
+// filter expressions -----
+
+func (p *parser) parseLogicalOr() (logicalExpression, error) {
+	var operands []logicalExpression
+	for {
+		operand, err := p.parseLogicalAnd()
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, operand)
+		savepoint := p.position
+		p.skipBlanks()
+		if !p.hasPrefix("||") {
+			p.position = savepoint
+			break
+		}
+		p.position += 2
+		p.skipBlanks()
+	}
+	if len(operands) == 1 {
+		return operands[0], nil
+	}
+	return &orExpression{operands: operands}, nil
+}
+
+func (p *parser) parseLogicalAnd() (logicalExpression, error) {
+	var operands []logicalExpression
+	for {
+		operand, err := p.parseBasicExpression()
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, operand)
+		savepoint := p.position
+		p.skipBlanks()
+		if !p.hasPrefix("&&") {
+			p.position = savepoint
+			break
+		}
+		p.position += 2
+		p.skipBlanks()
+	}
+	if len(operands) == 1 {
+		return operands[0], nil
+	}
+	return &andExpression{operands: operands}, nil
+}
+
+func (p *parser) parseBasicExpression() (logicalExpression, error) {
+	if p.current() == '!' && !p.hasPrefix("!=") {
+		p.position++
+		p.skipBlanks()
+		operand, err := p.parseNegatable()
+		if err != nil {
+			return nil, err
+		}
+		return &notExpression{operand: operand}, nil
+	}
+	if p.current() == '(' {
+		return p.parseParenExpression()
+	}
+	left, err := p.parseComparableOrTest()
+	if err != nil {
+		return nil, err
+	}
+	savepoint := p.position
+	p.skipBlanks()
+	operator := p.parseComparisonOperator()
+	if operator == "" {
+		p.position = savepoint
+		switch l := left.(type) {
+		case *filterQuery:
+			return &existsExpression{query: l}, nil
+		case *functionExpression:
+			return &functionTestExpression{function: l}, nil
+		default:
+			return nil, p.fail("Literal must be compared")
+		}
+	}
+	p.skipBlanks()
+	right, err := p.parseComparableOrTest()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.checkComparable(left); err != nil {
+		return nil, err
+	}
+	if err := p.checkComparable(right); err != nil {
+		return nil, err
+	}
+	return &comparisonExpression{left: left, operator: operator, right: right}, nil
+}
+
+func (p *parser) parseNegatable() (logicalExpression, error) {
+	if p.current() == '(' {
+		return p.parseParenExpression()
+	}
+	operand, err := p.parseComparableOrTest()
+	if err != nil {
+		return nil, err
+	}
+	switch o := operand.(type) {
+	case *filterQuery:
+		return &existsExpression{query: o}, nil
+	case *functionExpression:
+		return &functionTestExpression{function: o}, nil
+	default:
+		return nil, p.fail("Expected filter query or function after '!'")
+	}
+}
+
+func (p *parser) parseParenExpression() (logicalExpression, error) {
+	p.position++
+	p.skipBlanks()
+	expression, err := p.parseLogicalOr()
+	if err != nil {
+		return nil, err
+	}
+	p.skipBlanks()
+	if err := p.read(')'); err != nil {
+		return nil, err
+	}
+	return expression, nil
+}
+
+func (p *parser) parseComparisonOperator() string {
+	for _, operator := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		if p.hasPrefix(operator) {
+			p.position += len(operator)
+			return operator
+		}
+	}
+	return ""
+}
+
+func (p *parser) checkComparable(c comparable) error {
+	switch v := c.(type) {
+	case *filterQuery:
+		if !v.isSingular() {
+			return p.fail("Non-singular query " + v.String() + " can not be compared")
+		}
+	case *functionExpression:
+		if v.extension.result != ValueType {
+			return p.fail("Function " + v.extension.name + " does not return a value that can be compared")
+		}
+	}
+	return nil
+}
+
+// parseComparableOrTest reads a literal, a filter query or a function expression.
+func (p *parser) parseComparableOrTest() (comparable, error) {
+	c := p.current()
+	switch {
+	case !p.inBounds():
+		return nil, p.fail("Unexpected end of filter")
+	case c == '@' || c == '$':
+		p.position++
+		segments, err := p.parseSegments()
+		if err != nil {
+			return nil, err
+		}
+		return &filterQuery{relative: c == '@', segments: segments}, nil
+	case c == '\'' || c == '"':
+		begin := p.position
+		str, err := p.parseStringLiteral()
+		if err != nil {
+			return nil, err
+		}
+		return &literal{value0: str, text: string(p.runes[begin:p.position])}, nil
+	case c == '-' || isDigit(c):
+		return p.parseNumberLiteral()
+	case c >= 'a' && c <= 'z':
+		begin := p.position
+		for p.inBounds() && (c >= 'a' && c <= 'z' || c == '_' || isDigit(c)) {
+			p.position++
+			c = p.current()
+		}
+		name := string(p.runes[begin:p.position])
+		if p.current() == '(' {
+			return p.parseFunctionExpression(name)
+		}
+		switch name {
+		case "true":
+			return &literal{value0: true, text: name}, nil
+		case "false":
+			return &literal{value0: false, text: name}, nil
+		case "null":
+			return &literal{value0: nil, text: name}, nil
+		}
+		p.position = begin
+		return nil, p.fail("Unexpected name '" + name + "'")
+	default:
+		return nil, p.fail("Unexpected character '" + string(c) + "'")
+	}
+}
+
+func (p *parser) parseNumberLiteral() (comparable, error) {
+	begin := p.position
+	if p.current() == '-' {
+		p.position++
+	}
+	if !isDigit(p.current()) {
+		return nil, p.fail("Expected number")
+	}
+	if p.current() == '0' {
+		p.position++
+		if isDigit(p.current()) {
+			return nil, p.fail("Leading zeros are not allowed")
+		}
+	} else {
+		for isDigit(p.current()) {
+			p.position++
+		}
+	}
+	if p.current() == '.' {
+		p.position++
+		if !isDigit(p.current()) {
+			return nil, p.fail("Expected fraction digits")
+		}
+		for isDigit(p.current()) {
+			p.position++
+		}
+	}
+	if p.current() == 'e' || p.current() == 'E' {
+		p.position++
+		if p.current() == '-' || p.current() == '+' {
+			p.position++
+		}
+		if !isDigit(p.current()) {
+			return nil, p.fail("Expected exponent digits")
+		}
+		for isDigit(p.current()) {
+			p.position++
+		}
+	}
+	text := string(p.runes[begin:p.position])
+	number, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return nil, p.fail("Invalid number " + text)
+	}
+	return &literal{value0: number, text: text}, nil
+}
+
+func (p *parser) parseFunctionExpression(name string) (comparable, error) {
+	extension := functionExtensions[name]
+	if extension == nil {
+		return nil, p.fail("Unknown function '" + name + "'")
+	}
+	p.position++
+	p.skipBlanks()
+	var arguments []functionArgument
+	for p.current() != ')' || !p.inBounds() {
+		if len(arguments) > 0 {
+			if err := p.read(','); err != nil {
+				return nil, err
+			}
+			p.skipBlanks()
+		}
+		argument, err := p.parseFunctionArgument()
+		if err != nil {
+			return nil, err
+		}
+		arguments = append(arguments, argument)
+		p.skipBlanks()
+	}
+	p.position++
+	if len(arguments) != len(extension.parameters) {
+		return nil, p.fail("Function " + name + " expects " + strconv.Itoa(len(extension.parameters)) + " arguments")
+	}
+	for i, argument := range arguments {
+		if err := p.checkArgument(extension, extension.parameters[i], argument); err != nil {
+			return nil, err
+		}
+	}
+	return &functionExpression{extension: extension, arguments: arguments}, nil
+}
+
+func (p *parser) parseFunctionArgument() (functionArgument, error) {
+	savepoint := p.position
+	if c := p.current(); c == '\'' || c == '"' || c == '-' || isDigit(c) || c == 't' || c == 'f' || c == 'n' {
+		if l, err := p.parseComparableOrTest(); err == nil {
+			if lit, ok := l.(*literal); ok {
+				end := p.position
+				p.skipBlanks()
+				if p.current() == ',' || p.current() == ')' {
+					p.position = end
+					return lit, nil
+				}
+			}
+		}
+		p.position = savepoint
+	}
+	expression, err := p.parseLogicalOr()
+	if err != nil {
+		return nil, err
+	}
+	switch e := expression.(type) {
+	case *existsExpression:
+		return e.query, nil
+	case *functionTestExpression:
+		return e.function, nil
+	}
+	if err := checkTestExpressions(p, expression); err != nil {
+		return nil, err
+	}
+	return expression, nil
+}
+
+func (p *parser) checkArgument(extension *functionExtension, parameterType FunctionType, argument functionArgument) error {
+	valid := false
+	switch a := argument.(type) {
+	case *literal:
+		valid = parameterType == ValueType
+	case *filterQuery:
+		valid = parameterType == NodesType || parameterType == LogicalType || a.isSingular()
+	case *functionExpression:
+		switch parameterType {
+		case ValueType:
+			valid = a.extension.result == ValueType
+		case LogicalType:
+			valid = a.extension.result == LogicalType || a.extension.result == NodesType
+		case NodesType:
+			valid = a.extension.result == NodesType
+		}
+	case logicalExpression:
+		valid = parameterType == LogicalType
+	}
+	if !valid {
+		return p.fail("Argument " + argument.String() + " is not a valid " + parameterType.String() + " for function " + extension.name)
+	}
+	return nil
+}
+
+// checkTestExpressions verifies that functions used as test expressions return LogicalType or NodesType.
+func checkTestExpressions(p *parser, expression logicalExpression) error {
+	switch e := expression.(type) {
+	case *orExpression:
+		for _, operand := range e.operands {
+			if err := checkTestExpressions(p, operand); err != nil {
+				return err
+			}
+		}
+	case *andExpression:
+		for _, operand := range e.operands {
+			if err := checkTestExpressions(p, operand); err != nil {
+				return err
+			}
+		}
+	case *notExpression:
+		return checkTestExpressions(p, e.operand)
+	case *functionTestExpression:
+		if e.function.extension.result == ValueType {
+			return p.fail("Function " + e.function.extension.name + " result must be compared")
+		}
+	}
+	return nil
+}
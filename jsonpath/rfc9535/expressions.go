@@ -0,0 +1,278 @@
+package rfc9535
+
+import (
+	"github.com/CuiChao512/go-jsonpath/jsonpath/common"
+	"strings"
+)
+
+// Nothing is represented by common.JsonProviderUndefined, the same marker the json providers use
+// for an absent member.
+func isNothing(value interface{}) bool {
+	return value == common.JsonProviderUndefined
+}
+
+type logicalExpression interface {
+	test(ctx *evaluationContext, current *Node) bool
+	String() string
+}
+
+// orExpression -----
+type orExpression struct {
+	operands []logicalExpression
+}
+
+func (e *orExpression) test(ctx *evaluationContext, current *Node) bool {
+	for _, operand := range e.operands {
+		if operand.test(ctx, current) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *orExpression) String() string {
+	parts := make([]string, 0, len(e.operands))
+	for _, operand := range e.operands {
+		parts = append(parts, operand.String())
+	}
+	return strings.Join(parts, " || ")
+}
+
+// andExpression -----
+type andExpression struct {
+	operands []logicalExpression
+}
+
+func (e *andExpression) test(ctx *evaluationContext, current *Node) bool {
+	for _, operand := range e.operands {
+		if !operand.test(ctx, current) {
+			return false
+		}
+	}
+	return true
+}
+
+func (e *andExpression) String() string {
+	parts := make([]string, 0, len(e.operands))
+	for _, operand := range e.operands {
+		if _, ok := operand.(*orExpression); ok {
+			parts = append(parts, "("+operand.String()+")")
+		} else {
+			parts = append(parts, operand.String())
+		}
+	}
+	return strings.Join(parts, " && ")
+}
+
+// notExpression -----
+type notExpression struct {
+	operand logicalExpression
+}
+
+func (e *notExpression) test(ctx *evaluationContext, current *Node) bool {
+	return !e.operand.test(ctx, current)
+}
+
+func (e *notExpression) String() string {
+	switch e.operand.(type) {
+	case *existsExpression, *functionTestExpression:
+		return "!" + e.operand.String()
+	default:
+		return "!(" + e.operand.String() + ")"
+	}
+}
+
+// existsExpression is a test expression over a filter query: true if the query selects any node.
+type existsExpression struct {
+	query *filterQuery
+}
+
+func (e *existsExpression) test(ctx *evaluationContext, current *Node) bool {
+	return len(e.query.nodes(ctx, current)) > 0
+}
+
+func (e *existsExpression) String() string {
+	return e.query.String()
+}
+
+// functionTestExpression is a test expression over a function returning LogicalType or NodesType.
+type functionTestExpression struct {
+	function *functionExpression
+}
+
+func (e *functionTestExpression) test(ctx *evaluationContext, current *Node) bool {
+	return toLogical(e.function.call(ctx, current))
+}
+
+func (e *functionTestExpression) String() string {
+	return e.function.String()
+}
+
+// comparisonExpression -----
+type comparisonExpression struct {
+	left     comparable
+	operator string
+	right    comparable
+}
+
+func (e *comparisonExpression) test(ctx *evaluationContext, current *Node) bool {
+	left := e.left.value(ctx, current)
+	right := e.right.value(ctx, current)
+	switch e.operator {
+	case "==":
+		return compareEquals(left, right)
+	case "!=":
+		return !compareEquals(left, right)
+	case "<":
+		return compareLess(left, right)
+	case "<=":
+		return compareLess(left, right) || compareEquals(left, right)
+	case ">":
+		return compareLess(right, left)
+	case ">=":
+		return compareLess(right, left) || compareEquals(left, right)
+	}
+	return false
+}
+
+func (e *comparisonExpression) String() string {
+	return e.left.String() + " " + e.operator + " " + e.right.String()
+}
+
+// comparable is a literal, a singular query or a function expression of ValueType.
+type comparable interface {
+	value(ctx *evaluationContext, current *Node) interface{}
+	String() string
+}
+
+// literal -----
+type literal struct {
+	value0 interface{}
+	text   string
+}
+
+func (l *literal) value(ctx *evaluationContext, current *Node) interface{} {
+	return l.value0
+}
+
+func (l *literal) String() string {
+	return l.text
+}
+
+// filterQuery is a relative (@) or absolute ($) query inside a filter expression.
+type filterQuery struct {
+	relative bool
+	segments []*segment
+}
+
+func (q *filterQuery) nodes(ctx *evaluationContext, current *Node) NodeList {
+	if q.relative {
+		return ctx.apply(q.segments, NodeList{{location: current.location, value: current.value}})
+	}
+	return ctx.apply(q.segments, NodeList{{location: "$", value: ctx.root}})
+}
+
+func (q *filterQuery) value(ctx *evaluationContext, current *Node) interface{} {
+	nodes := q.nodes(ctx, current)
+	if len(nodes) == 1 {
+		return nodes[0].value
+	}
+	return common.JsonProviderUndefined
+}
+
+func (q *filterQuery) isSingular() bool {
+	return isSingular(q.segments)
+}
+
+func (q *filterQuery) String() string {
+	sb := &strings.Builder{}
+	if q.relative {
+		sb.WriteRune('@')
+	} else {
+		sb.WriteRune('$')
+	}
+	for _, s := range q.segments {
+		sb.WriteString(s.String())
+	}
+	return sb.String()
+}
+
+func compareEquals(left interface{}, right interface{}) bool {
+	if isNothing(left) || isNothing(right) {
+		return isNothing(left) && isNothing(right)
+	}
+	return valuesEqual(left, right)
+}
+
+func compareLess(left interface{}, right interface{}) bool {
+	if l, ok := toNumber(left); ok {
+		if r, ok := toNumber(right); ok {
+			return l < r
+		}
+		return false
+	}
+	if l, ok := left.(string); ok {
+		if r, ok := right.(string); ok {
+			// byte order of UTF-8 strings equals the order of their Unicode scalar values
+			return l < r
+		}
+	}
+	return false
+}
+
+func valuesEqual(left interface{}, right interface{}) bool {
+	if l, ok := toNumber(left); ok {
+		r, ok := toNumber(right)
+		return ok && l == r
+	}
+	switch l := left.(type) {
+	case nil:
+		return right == nil
+	case string:
+		r, ok := right.(string)
+		return ok && l == r
+	case bool:
+		r, ok := right.(bool)
+		return ok && l == r
+	}
+	if common.UtilsIsSlice(left) {
+		if !common.UtilsIsSlice(right) {
+			return false
+		}
+		l, err := common.ConvertToAnySlice(left)
+		if err != nil {
+			return false
+		}
+		r, err := common.ConvertToAnySlice(right)
+		if err != nil || len(l) != len(r) {
+			return false
+		}
+		for i := range l {
+			if !valuesEqual(l[i], r[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	if common.UtilsIsMap(left) {
+		if !common.UtilsIsMap(right) {
+			return false
+		}
+		l, err := common.ConvertToStringAnyMap(left)
+		if err != nil {
+			return false
+		}
+		r, err := common.ConvertToStringAnyMap(right)
+		if err != nil || len(l) != len(r) {
+			return false
+		}
+		for k, lv := range l {
+			rv, ok := r[k]
+			if !ok || !valuesEqual(lv, rv) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
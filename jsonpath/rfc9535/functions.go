@@ -0,0 +1,222 @@
+package rfc9535
+
+import (
+	"github.com/CuiChao512/go-jsonpath/jsonpath/common"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// FunctionType is the declared type of a function extension parameter or result.
+type FunctionType int
+
+const (
+	ValueType   FunctionType = 0
+	LogicalType FunctionType = 1
+	NodesType   FunctionType = 2
+)
+
+func (t FunctionType) String() string {
+	switch t {
+	case ValueType:
+		return "ValueType"
+	case LogicalType:
+		return "LogicalType"
+	default:
+		return "NodesType"
+	}
+}
+
+type functionExtension struct {
+	name       string
+	parameters []FunctionType
+	result     FunctionType
+	call       func(ctx *evaluationContext, args []interface{}) interface{}
+}
+
+var functionExtensions = map[string]*functionExtension{
+	"length": {name: "length", parameters: []FunctionType{ValueType}, result: ValueType, call: lengthFunction},
+	"count":  {name: "count", parameters: []FunctionType{NodesType}, result: ValueType, call: countFunction},
+	"match":  {name: "match", parameters: []FunctionType{ValueType, ValueType}, result: LogicalType, call: matchFunction},
+	"search": {name: "search", parameters: []FunctionType{ValueType, ValueType}, result: LogicalType, call: searchFunction},
+	"value":  {name: "value", parameters: []FunctionType{NodesType}, result: ValueType, call: valueFunction},
+}
+
+func lengthFunction(ctx *evaluationContext, args []interface{}) interface{} {
+	value := args[0]
+	if str, ok := value.(string); ok {
+		return float64(utf8.RuneCountInString(str))
+	}
+	if ctx.provider.IsArray(value) || ctx.provider.IsMap(value) {
+		if length, err := ctx.provider.Length(value); err == nil {
+			return float64(length)
+		}
+	}
+	return common.JsonProviderUndefined
+}
+
+func countFunction(ctx *evaluationContext, args []interface{}) interface{} {
+	nodes, _ := args[0].(NodeList)
+	return float64(len(nodes))
+}
+
+func valueFunction(ctx *evaluationContext, args []interface{}) interface{} {
+	nodes, _ := args[0].(NodeList)
+	if len(nodes) == 1 {
+		return nodes[0].value
+	}
+	return common.JsonProviderUndefined
+}
+
+func matchFunction(ctx *evaluationContext, args []interface{}) interface{} {
+	return regexpFunction(args, true)
+}
+
+func searchFunction(ctx *evaluationContext, args []interface{}) interface{} {
+	return regexpFunction(args, false)
+}
+
+func regexpFunction(args []interface{}, fullMatch bool) bool {
+	input, ok := args[0].(string)
+	if !ok {
+		return false
+	}
+	pattern, ok := args[1].(string)
+	if !ok {
+		return false
+	}
+	re := compileIRegexp(pattern, fullMatch)
+	if re == nil {
+		return false
+	}
+	return re.MatchString(input)
+}
+
+var regexpCache = sync.Map{}
+
+type regexpCacheKey struct {
+	pattern   string
+	fullMatch bool
+}
+
+// compileIRegexp translates an I-Regexp (RFC 9485) to a Go regular expression. It returns nil if the
+// pattern is invalid.
+func compileIRegexp(pattern string, fullMatch bool) *regexp.Regexp {
+	key := regexpCacheKey{pattern: pattern, fullMatch: fullMatch}
+	if cached, ok := regexpCache.Load(key); ok {
+		re, _ := cached.(*regexp.Regexp)
+		return re
+	}
+	sb := &strings.Builder{}
+	inClass := false
+	escaped := false
+	for _, r := range pattern {
+		switch {
+		case escaped:
+			escaped = false
+			sb.WriteRune(r)
+		case r == '\\':
+			escaped = true
+			sb.WriteRune(r)
+		case r == '[' && !inClass:
+			inClass = true
+			sb.WriteRune(r)
+		case r == ']' && inClass:
+			inClass = false
+			sb.WriteRune(r)
+		case r == '.' && !inClass:
+			// "." does not match line terminators in I-Regexp
+			sb.WriteString("[^\\n\\r]")
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	expression := sb.String()
+	if fullMatch {
+		expression = "^(?:" + expression + ")$"
+	}
+	re, err := regexp.Compile(expression)
+	if err != nil {
+		re = nil
+	}
+	regexpCache.Store(key, re)
+	return re
+}
+
+// functionArgument -----
+type functionArgument interface {
+	String() string
+}
+
+type functionExpression struct {
+	extension *functionExtension
+	arguments []functionArgument
+}
+
+func (f *functionExpression) call(ctx *evaluationContext, current *Node) interface{} {
+	args := make([]interface{}, len(f.arguments))
+	for i, argument := range f.arguments {
+		args[i] = f.argumentValue(ctx, current, f.extension.parameters[i], argument)
+	}
+	return f.extension.call(ctx, args)
+}
+
+func (f *functionExpression) argumentValue(ctx *evaluationContext, current *Node, parameterType FunctionType, argument functionArgument) interface{} {
+	switch parameterType {
+	case ValueType:
+		switch a := argument.(type) {
+		case comparable:
+			return a.value(ctx, current)
+		}
+	case LogicalType:
+		switch a := argument.(type) {
+		case *filterQuery:
+			return len(a.nodes(ctx, current)) > 0
+		case *functionExpression:
+			return toLogical(a.call(ctx, current))
+		case logicalExpression:
+			return a.test(ctx, current)
+		}
+	case NodesType:
+		switch a := argument.(type) {
+		case *filterQuery:
+			return a.nodes(ctx, current)
+		case *functionExpression:
+			return a.call(ctx, current)
+		}
+	}
+	return common.JsonProviderUndefined
+}
+
+// value makes a ValueType function usable as a comparable.
+func (f *functionExpression) value(ctx *evaluationContext, current *Node) interface{} {
+	return f.call(ctx, current)
+}
+
+func (f *functionExpression) String() string {
+	parts := make([]string, 0, len(f.arguments))
+	for _, argument := range f.arguments {
+		parts = append(parts, argument.String())
+	}
+	return f.extension.name + "(" + strings.Join(parts, ", ") + ")"
+}
+
+func toLogical(result interface{}) bool {
+	switch r := result.(type) {
+	case bool:
+		return r
+	case NodeList:
+		return len(r) > 0
+	default:
+		return false
+	}
+}
+
+func toNumber(value interface{}) (float64, bool) {
+	if !common.UtilsIsNumber(value) {
+		return 0, false
+	}
+	number, err := common.UtilsNumberToFloat64(value)
+	return number, err == nil
+}
@@ -0,0 +1,226 @@
+// Package rfc9535 compiles and evaluates JSONPath queries strictly according to RFC 9535.
+//
+// The default compiler in package filter follows Jayway JsonPath. Queries compiled here use the
+// standard selector grammar, always produce a nodelist, report normalized paths and support the
+// standard function extensions length, count, match, search and value.
+package rfc9535
+
+import (
+	"github.com/CuiChao512/go-jsonpath/jsonpath/common"
+	"github.com/CuiChao512/go-jsonpath/jsonpath/path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Node is a single result of a query: a value and its normalized path.
+type Node struct {
+	location string
+	value    interface{}
+	parent   interface{}
+	key      interface{}
+}
+
+func (n *Node) Location() string {
+	return n.location
+}
+
+func (n *Node) Value() interface{} {
+	return n.value
+}
+
+func (n *Node) pathRef() common.PathRef {
+	switch k := n.key.(type) {
+	case string:
+		return path.CreateObjectPropertyPathRef(n.parent, k)
+	case int:
+		return path.CreateArrayIndexPathRef(n.parent, k)
+	default:
+		return path.CreateRootPathRef(n.value)
+	}
+}
+
+func (n *Node) member(name string, value interface{}) *Node {
+	return &Node{location: n.location + "[" + NormalizedName(name) + "]", value: value, parent: n.value, key: name}
+}
+
+func (n *Node) element(index int, value interface{}) *Node {
+	return &Node{location: n.location + "[" + strconv.Itoa(index) + "]", value: value, parent: n.value, key: index}
+}
+
+type NodeList []*Node
+
+func (l NodeList) Values() []interface{} {
+	values := make([]interface{}, 0, len(l))
+	for _, n := range l {
+		values = append(values, n.value)
+	}
+	return values
+}
+
+func (l NodeList) Locations() []string {
+	locations := make([]string, 0, len(l))
+	for _, n := range l {
+		locations = append(locations, n.location)
+	}
+	return locations
+}
+
+// Query is a compiled RFC 9535 query. It implements common.Path so that it can be used wherever a
+// Jayway compiled path is accepted.
+type Query struct {
+	segments []*segment
+	source   string
+}
+
+func (q *Query) Select(document interface{}, configuration *common.Configuration) NodeList {
+	ctx := &evaluationContext{provider: configuration.JsonProvider(), root: document}
+	return ctx.apply(q.segments, NodeList{&Node{location: "$", value: document}})
+}
+
+func (q *Query) Evaluate(document interface{}, rootDocument interface{}, configuration *common.Configuration) (common.EvaluationContext, error) {
+	return q.EvaluateForUpdate(document, rootDocument, configuration, false)
+}
+
+func (q *Query) EvaluateForUpdate(document interface{}, rootDocument interface{}, configuration *common.Configuration, forUpdate bool) (common.EvaluationContext, error) {
+	ctx := path.CreateEvaluationContextImpl(q, rootDocument, configuration, forUpdate)
+	for _, n := range q.Select(document, configuration) {
+		var ref common.PathRef
+		if forUpdate {
+			ref = n.pathRef()
+		} else {
+			ref = path.PathRefNoOp
+		}
+		if err := ctx.AddResult(n.location, ref, n.value); err != nil {
+			return nil, err
+		}
+	}
+	return ctx, nil
+}
+
+func (q *Query) String() string {
+	sb := &strings.Builder{}
+	sb.WriteString("$")
+	for _, s := range q.segments {
+		sb.WriteString(s.String())
+	}
+	return sb.String()
+}
+
+// IsDefinite is always false: a standard query produces a nodelist, even if it is singular.
+func (q *Query) IsDefinite() bool {
+	return false
+}
+
+func (q *Query) IsSingular() bool {
+	return isSingular(q.segments)
+}
+
+func (q *Query) IsFunctionPath() bool {
+	return false
+}
+
+func (q *Query) IsRootPath() bool {
+	return true
+}
+
+func Compile(query string) (*Query, error) {
+	p := createParser(query)
+	segments, err := p.parseQuery()
+	if err != nil {
+		return nil, err
+	}
+	return &Query{segments: segments, source: query}, nil
+}
+
+// NormalizedName renders a member name as a normalized path name selector.
+func NormalizedName(name string) string {
+	sb := &strings.Builder{}
+	sb.WriteRune('\'')
+	for _, r := range name {
+		switch r {
+		case '\b':
+			sb.WriteString("\\b")
+		case '\f':
+			sb.WriteString("\\f")
+		case '\n':
+			sb.WriteString("\\n")
+		case '\r':
+			sb.WriteString("\\r")
+		case '\t':
+			sb.WriteString("\\t")
+		case '\'':
+			sb.WriteString("\\'")
+		case '\\':
+			sb.WriteString("\\\\")
+		default:
+			if r < 0x20 {
+				sb.WriteString("\\u00")
+				hex := strconv.FormatInt(int64(r), 16)
+				if len(hex) < 2 {
+					sb.WriteRune('0')
+				}
+				sb.WriteString(hex)
+			} else {
+				sb.WriteRune(r)
+			}
+		}
+	}
+	sb.WriteRune('\'')
+	return sb.String()
+}
+
+type evaluationContext struct {
+	provider common.JsonProvider
+	root     interface{}
+}
+
+func (ctx *evaluationContext) apply(segments []*segment, input NodeList) NodeList {
+	nodes := input
+	for _, s := range segments {
+		var output NodeList
+		for _, n := range nodes {
+			output = s.apply(ctx, n, output)
+		}
+		nodes = output
+	}
+	return nodes
+}
+
+func (ctx *evaluationContext) keys(value interface{}) []string {
+	keys, err := ctx.provider.GetPropertyKeys(value)
+	if err != nil {
+		return nil
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (ctx *evaluationContext) elements(value interface{}) []interface{} {
+	elements, err := ctx.provider.ToArray(value)
+	if err != nil {
+		return nil
+	}
+	return elements
+}
+
+func (ctx *evaluationContext) children(n *Node) NodeList {
+	var children NodeList
+	if ctx.provider.IsArray(n.value) {
+		for i, e := range ctx.elements(n.value) {
+			children = append(children, n.element(i, e))
+		}
+	} else if ctx.provider.IsMap(n.value) {
+		for _, k := range ctx.keys(n.value) {
+			children = append(children, n.member(k, ctx.provider.GetMapValue(n.value, k)))
+		}
+	}
+	return children
+}
+
+func (ctx *evaluationContext) descendants(n *Node, visit func(*Node)) {
+	visit(n)
+	for _, child := range ctx.children(n) {
+		ctx.descendants(child, visit)
+	}
+}
@@ -0,0 +1,211 @@
+package rfc9535
+
+import (
+	"github.com/CuiChao512/go-jsonpath/jsonpath/common"
+	"strconv"
+	"strings"
+)
+
+type segment struct {
+	descendant bool
+	selectors  []selector
+}
+
+func (s *segment) apply(ctx *evaluationContext, n *Node, output NodeList) NodeList {
+	if s.descendant {
+		ctx.descendants(n, func(d *Node) {
+			for _, sel := range s.selectors {
+				output = sel.selectNodes(ctx, d, output)
+			}
+		})
+		return output
+	}
+	for _, sel := range s.selectors {
+		output = sel.selectNodes(ctx, n, output)
+	}
+	return output
+}
+
+func (s *segment) isSingular() bool {
+	if s.descendant || len(s.selectors) != 1 {
+		return false
+	}
+	switch s.selectors[0].(type) {
+	case *nameSelector, *indexSelector:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s *segment) String() string {
+	parts := make([]string, 0, len(s.selectors))
+	for _, sel := range s.selectors {
+		parts = append(parts, sel.String())
+	}
+	prefix := ""
+	if s.descendant {
+		prefix = ".."
+	}
+	return prefix + "[" + strings.Join(parts, ",") + "]"
+}
+
+func isSingular(segments []*segment) bool {
+	for _, s := range segments {
+		if !s.isSingular() {
+			return false
+		}
+	}
+	return true
+}
+
+type selector interface {
+	selectNodes(ctx *evaluationContext, n *Node, output NodeList) NodeList
+	String() string
+}
+
+// nameSelector -----
+type nameSelector struct {
+	name string
+}
+
+func (s *nameSelector) selectNodes(ctx *evaluationContext, n *Node, output NodeList) NodeList {
+	if !ctx.provider.IsMap(n.value) {
+		return output
+	}
+	if value := ctx.provider.GetMapValue(n.value, s.name); !isNothing(value) {
+		output = append(output, n.member(s.name, value))
+	}
+	return output
+}
+
+func (s *nameSelector) String() string {
+	return NormalizedName(s.name)
+}
+
+// wildcardSelector -----
+type wildcardSelector struct {
+}
+
+func (*wildcardSelector) selectNodes(ctx *evaluationContext, n *Node, output NodeList) NodeList {
+	return append(output, ctx.children(n)...)
+}
+
+func (*wildcardSelector) String() string {
+	return "*"
+}
+
+// indexSelector -----
+type indexSelector struct {
+	index int
+}
+
+func (s *indexSelector) selectNodes(ctx *evaluationContext, n *Node, output NodeList) NodeList {
+	if !ctx.provider.IsArray(n.value) {
+		return output
+	}
+	elements := ctx.elements(n.value)
+	index := s.index
+	if index < 0 {
+		index += len(elements)
+	}
+	if index >= 0 && index < len(elements) {
+		output = append(output, n.element(index, elements[index]))
+	}
+	return output
+}
+
+func (s *indexSelector) String() string {
+	return strconv.Itoa(s.index)
+}
+
+// sliceSelector -----
+type sliceSelector struct {
+	start *int
+	end   *int
+	step  *int
+}
+
+func (s *sliceSelector) bounds(length int) (int, int, int) {
+	step := 1
+	if s.step != nil {
+		step = *s.step
+	}
+	normalize := func(i int) int {
+		if i >= 0 {
+			return i
+		}
+		return length + i
+	}
+	var start, end int
+	if step >= 0 {
+		start, end = 0, length
+	} else {
+		start, end = length-1, -length-1
+	}
+	if s.start != nil {
+		start = *s.start
+	}
+	if s.end != nil {
+		end = *s.end
+	}
+	start, end = normalize(start), normalize(end)
+	if step >= 0 {
+		lower := common.UtilsMinInt(common.UtilsMaxInt(start, 0), length)
+		upper := common.UtilsMinInt(common.UtilsMaxInt(end, 0), length)
+		return lower, upper, step
+	}
+	upper := common.UtilsMinInt(common.UtilsMaxInt(start, -1), length-1)
+	lower := common.UtilsMinInt(common.UtilsMaxInt(end, -1), length-1)
+	return upper, lower, step
+}
+
+func (s *sliceSelector) selectNodes(ctx *evaluationContext, n *Node, output NodeList) NodeList {
+	if !ctx.provider.IsArray(n.value) {
+		return output
+	}
+	elements := ctx.elements(n.value)
+	from, to, step := s.bounds(len(elements))
+	if step > 0 {
+		for i := from; i < to; i += step {
+			output = append(output, n.element(i, elements[i]))
+		}
+	} else if step < 0 {
+		for i := from; to < i; i += step {
+			output = append(output, n.element(i, elements[i]))
+		}
+	}
+	return output
+}
+
+func (s *sliceSelector) String() string {
+	str := func(i *int) string {
+		if i == nil {
+			return ""
+		}
+		return strconv.Itoa(*i)
+	}
+	result := str(s.start) + ":" + str(s.end)
+	if s.step != nil {
+		result += ":" + str(s.step)
+	}
+	return result
+}
+
+// filterSelector -----
+type filterSelector struct {
+	expression logicalExpression
+}
+
+func (s *filterSelector) selectNodes(ctx *evaluationContext, n *Node, output NodeList) NodeList {
+	for _, child := range ctx.children(n) {
+		if s.expression.test(ctx, child) {
+			output = append(output, child)
+		}
+	}
+	return output
+}
+
+func (s *filterSelector) String() string {
+	return "?" + s.expression.String()
+}
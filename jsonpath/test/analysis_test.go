@@ -0,0 +1,87 @@
+package test
+
+import (
+	"github.com/CuiChao512/go-jsonpath/jsonpath/analysis"
+	"github.com/CuiChao512/go-jsonpath/jsonpath/common"
+	"github.com/CuiChao512/go-jsonpath/jsonpath/filter"
+	"testing"
+)
+
+type analysisTestData struct {
+	a        string
+	b        string
+	expected analysis.Answer
+}
+
+var overlapsTestDatas = []analysisTestData{
+	{a: "$.users[*].name", b: "$.users[0].name", expected: analysis.YES},
+	{a: "$.users[*].name", b: "$.users[*].email", expected: analysis.NO},
+	{a: "$..name", b: "$.a.b.name", expected: analysis.YES},
+	{a: "$..name", b: "$.a.b.email", expected: analysis.NO},
+	{a: "$.a[-1]", b: "$.a[2]", expected: analysis.YES},
+	{a: "$.a[1:3]", b: "$.a[3]", expected: analysis.NO},
+	{a: "$.a[:2]", b: "$.a[-1]", expected: analysis.YES},
+	{a: "$.a[3:1]", b: "$.a[*]", expected: analysis.NO},
+	{a: "$.a['x','y']", b: "$.a.y", expected: analysis.YES},
+	{a: "$.a.x", b: "$.a[0]", expected: analysis.NO},
+	{a: "$.users[?(@.id == 1)].name", b: "$.users[0].name", expected: analysis.MAYBE},
+	{a: "$.users[?(@.id == 1)].name", b: "$.users[0].email", expected: analysis.NO},
+	{a: "$.m[?(@.b)]", b: "$.m['0']", expected: analysis.MAYBE},
+	{a: "$.m[?(@.b)].x", b: "$.m.k.x", expected: analysis.MAYBE},
+	{a: "$.a[?(@.b)]", b: "$.a[3:1]", expected: analysis.NO},
+	{a: "$.a.length()", b: "$.a", expected: analysis.MAYBE},
+}
+
+var containsTestDatas = []analysisTestData{
+	{a: "$.users[*].name", b: "$.users[0].name", expected: analysis.YES},
+	{a: "$.users[0].name", b: "$.users[*].name", expected: analysis.NO},
+	{a: "$..name", b: "$.a..b.name", expected: analysis.YES},
+	{a: "$.a.name", b: "$..name", expected: analysis.NO},
+	{a: "$.a[*]", b: "$.a[1:3]", expected: analysis.YES},
+	{a: "$.a[:3]", b: "$.a[0,2]", expected: analysis.YES},
+	{a: "$.a[:3]", b: "$.a[0,-1]", expected: analysis.NO},
+	{a: "$.a['x','y']", b: "$.a.y", expected: analysis.YES},
+	{a: "$.a[*]", b: "$.a[?(@.x)]", expected: analysis.MAYBE},
+	{a: "$.a[?(@.x)]", b: "$.a[0]", expected: analysis.MAYBE},
+}
+
+var isPrefixTestDatas = []analysisTestData{
+	{a: "$.users[*]", b: "$.users[0].name", expected: analysis.YES},
+	{a: "$.users", b: "$.users", expected: analysis.YES},
+	{a: "$.users[0]", b: "$.users[*].name", expected: analysis.NO},
+	{a: "$.users.name", b: "$.users", expected: analysis.NO},
+	{a: "$.admins", b: "$.users[0].name", expected: analysis.NO},
+	{a: "$..secret", b: "$.a.secret.value", expected: analysis.YES},
+	{a: "$.users[?(@.active)]", b: "$.users[0].name", expected: analysis.MAYBE},
+}
+
+func assertAnswers(t *testing.T, question string, datas []analysisTestData, answer func(a common.Path, b common.Path) (analysis.Answer, error)) {
+	for _, data := range datas {
+		a, err := filter.PathCompile(data.a)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := filter.PathCompile(data.b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		result, err := answer(a, b)
+		if err != nil {
+			t.Errorf("%s %s %s: %v", data.a, question, data.b, err)
+		} else if result != data.expected {
+			t.Errorf("%s %s %s: expected %s, got %s", data.a, question, data.b, data.expected, result)
+		}
+	}
+}
+
+func TestOverlaps(t *testing.T) {
+	assertAnswers(t, "overlaps", overlapsTestDatas, analysis.Overlaps)
+}
+
+func TestContains(t *testing.T) {
+	assertAnswers(t, "contains", containsTestDatas, analysis.Contains)
+}
+
+func TestIsPrefix(t *testing.T) {
+	assertAnswers(t, "is a prefix of", isPrefixTestDatas, analysis.IsPrefix)
+}
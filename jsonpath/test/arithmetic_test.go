@@ -0,0 +1,55 @@
+package test
+
+import (
+	"github.com/CuiChao512/go-jsonpath/jsonpath"
+	"reflect"
+	"testing"
+)
+
+var arithmeticTestDocument = "{\n" +
+	"  \"orders\": [\n" +
+	"    {\"id\": 1, \"price\": 10.1, \"qty\": 10, \"start\": 0, \"end\": 3600, \"total\": 4},\n" +
+	"    {\"id\": 2, \"price\": 0.1, \"qty\": 3, \"start\": 100, \"end\": 200, \"total\": 5},\n" +
+	"    {\"id\": 3, \"price\": \"0.2\", \"qty\": 1, \"start\": 0, \"end\": 7200, \"total\": \"n/a\"}\n" +
+	"  ]\n" +
+	"}"
+
+var arithmeticTestDatas = []parentTestData{
+	{path: "$.orders[?(@.price * @.qty > 100)].id", expect: []interface{}{float64(1)}},
+	{path: "$.orders[?(@.end - @.start >= 3600)].id", expect: []interface{}{float64(1), float64(3)}},
+	{path: "$.orders[?(@.total % 2 == 0)].id", expect: []interface{}{float64(1)}},
+	{path: "$.orders[?(@.price + 0.2 == 0.3)].id", expect: []interface{}{float64(2)}},
+	{path: "$.orders[?(@.price + @.price * 2 == 0.6)].id", expect: []interface{}{float64(3)}},
+	{path: "$.orders[?((@.price + @.price) * 2 == 0.4)].id", expect: []interface{}{float64(2)}},
+	{path: "$.orders[?(-@.qty < -2)].id", expect: []interface{}{float64(1), float64(2)}},
+	{path: "$.orders[?(@.qty > (@.id - 1) * 5)].id", expect: []interface{}{float64(1)}},
+	{path: "$.orders[?(@.end / @.start > 1 || @.id == 3)].id", expect: []interface{}{float64(2), float64(3)}},
+	{path: "$.orders[?(@.qty == 10 - 7)].id", expect: []interface{}{float64(2)}},
+	{path: "$.orders[?(@.missing + 1 > 0)].id", expect: []interface{}{}},
+	{path: "$.orders[?(@.price*@.qty > 100)].id", expect: []interface{}{float64(1)}},
+	{path: "$.orders[?(@.qty-1 == 9)].id", expect: []interface{}{float64(1)}},
+	{path: "$.orders[?(@.end-@.start>=3600)].id", expect: []interface{}{float64(1), float64(3)}},
+	{path: "$.orders[?(@.total%2 == 0)].id", expect: []interface{}{float64(1)}},
+	{path: "$.orders[?(@.price+0.2 == 0.3)].id", expect: []interface{}{float64(2)}},
+	{path: "$.orders[?(@.end/@.start > 1)].id", expect: []interface{}{float64(2)}},
+	{path: "$.orders[?(@.qty*2-1 == 5)].id", expect: []interface{}{float64(2)}},
+	{path: "$.orders[?(@['qty']*2 == 6)].id", expect: []interface{}{float64(2)}},
+	{path: "$.orders[?(@.first-name)].id", expect: []interface{}{}},
+}
+
+func Test_filter_arithmetic(t *testing.T) {
+	for _, data := range arithmeticTestDatas {
+		documentContext, err := jsonpath.ParseString(arithmeticTestDocument)
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+		result, err := documentContext.Read(data.path)
+		if err != nil {
+			t.Errorf("%s: %s", data.path, err.Error())
+			continue
+		}
+		if !reflect.DeepEqual(result, data.expect) {
+			t.Errorf("%s: expected %v, got %v", data.path, data.expect, result)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package test
+
+import (
+	"github.com/CuiChao512/go-jsonpath/jsonpath"
+	"github.com/CuiChao512/go-jsonpath/jsonpath/common"
+	"reflect"
+	"testing"
+)
+
+var arrayIndexTestDocument = `{"a": [0, 1, 2, 3]}`
+
+var arrayIndexTestDatas = []parentTestData{
+	{path: "$.a[-1]", expect: []interface{}{float64(3)}},
+	{path: "$.a[-4]", expect: []interface{}{float64(0)}},
+	{path: "$.a[-5]", expect: []interface{}{}},
+	{path: "$.a[-9,-1]", expect: []interface{}{float64(3)}},
+	{path: "$.a[-9:2]", expect: []interface{}{float64(0), float64(1)}},
+	{path: "$.a[-9007199254740991:2]", expect: []interface{}{float64(0), float64(1)}},
+	{path: "$.a[-2:9]", expect: []interface{}{float64(2), float64(3)}},
+}
+
+// indexes and slice starts before the start of the array select nothing of it instead of panicking
+func TestNegativeArrayIndexes(t *testing.T) {
+	conf := common.DefaultConfiguration().AddOptions(common.OPTION_RFC9535)
+	documentContext, err := jsonpath.CreateParseContextImplByConfiguration(conf).ParseString(arrayIndexTestDocument)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, data := range arrayIndexTestDatas {
+		result, err := documentContext.Read(data.path)
+		if err != nil {
+			t.Errorf("%s: %v", data.path, err)
+			continue
+		}
+		if !reflect.DeepEqual(result, data.expect) {
+			t.Errorf("%s: expected %v, got %v", data.path, data.expect, result)
+		}
+	}
+}
+
+func TestNegativeArrayIndexOutOfBounds(t *testing.T) {
+	documentContext, err := jsonpath.ParseString(arrayIndexTestDocument)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = documentContext.Read("$.a[-5]"); err == nil {
+		t.Errorf("expected an error for an index before the start of the array")
+	}
+}
@@ -0,0 +1,154 @@
+package test
+
+import (
+	"github.com/CuiChao512/go-jsonpath/jsonpath/ast"
+	"github.com/CuiChao512/go-jsonpath/jsonpath/common"
+	"github.com/CuiChao512/go-jsonpath/jsonpath/filter"
+	"reflect"
+	"testing"
+)
+
+type astTestData struct {
+	path      string
+	canonical string
+}
+
+var astTestDatas = []astTestData{
+	{path: "$.store.book[0].title", canonical: "$['store']['book'][0]['title']"},
+	{path: "store['a','b']", canonical: "$['store']['a','b']"},
+	{path: `$['o\'neil']['a\\b']`, canonical: `$['o\'neil']['a\\b']`},
+	{path: "$..book[*].author", canonical: "$..['book'][*]['author']"},
+	{path: "$..*", canonical: "$..[*]"},
+	{path: "$.a[1:].b[:2][1:3][0,-1]", canonical: "$['a'][1:]['b'][:2][1:3][0,-1]"},
+	{path: "$.a[?(@.b > 1 && @.c == 'x')].d", canonical: "$['a'][?(@['b'] > 1 && @['c'] == 'x')]['d']"},
+	{path: "$.a[?(@.b)][?(@.c)]", canonical: "$['a'][?(@['b'])][?(@['c'])]"},
+	{path: "$.a[?(!(@.b == 1))]", canonical: "$['a'][?(!(@['b'] == 1))]"},
+	{path: "$.a[?(!@.b)]", canonical: "$['a'][?(!@['b'])]"},
+	{path: "$.a[?(@.b > 1 && !(@.c == 'x' || @.d))]", canonical: "$['a'][?(@['b'] > 1 && !(@['c'] == 'x' || @['d']))]"},
+	{path: "$.a.length()", canonical: "$['a'].length()"},
+	{path: "$..a.length()", canonical: "$..['a'].length()"},
+	{path: "$.a.concat('-', @.b.length(), 1)", canonical: `$['a'].concat("-", @['b'].length(), 1)`},
+	{path: "$.a^~", canonical: "$['a']^~"},
+	{path: "@.a", canonical: "@['a']"},
+}
+
+func TestAstRoundTrip(t *testing.T) {
+	for _, data := range astTestDatas {
+		tree, err := ast.Parse(data.path)
+		if err != nil {
+			t.Errorf("%s: %v", data.path, err)
+			continue
+		}
+		if tree.String() != data.canonical {
+			t.Errorf("%s: expected %s, got %s", data.path, data.canonical, tree.String())
+			continue
+		}
+		reparsed, err := ast.Parse(tree.String())
+		if err != nil || reparsed.String() != data.canonical {
+			t.Errorf("%s: %s does not round trip: %v %v", data.path, data.canonical, reparsed, err)
+		}
+	}
+}
+
+func TestAstBuilder(t *testing.T) {
+	expensive, err := filter.Compile("[?(@['display-price'] > 10)]")
+	if err != nil {
+		t.Fatal(err)
+	}
+	base := ast.Root().Field("store").Field("book")
+	tree := base.Filter(expensive).Field("title")
+	if tree.String() != "$['store']['book'][?(@['display-price'] > 10)]['title']" {
+		t.Errorf("unexpected %s", tree.String())
+	}
+	if base.String() != "$['store']['book']" {
+		t.Errorf("the builder changed the path it was called on: %s", base.String())
+	}
+
+	compiled, err := tree.Compile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, err := getParseContextUsingDefaultConf().ParseString(TestJsonDocument)
+	if err != nil {
+		t.Fatal(err)
+	}
+	evaluated, err := compiled.Evaluate(ctx.Json(), ctx.Json(), ctx.Configuration())
+	if err != nil {
+		t.Fatal(err)
+	}
+	value, err := evaluated.GetValue()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []interface{}{"Sword of Honour", "The Lord of the Rings"}
+	if !reflect.DeepEqual(value, expected) {
+		t.Errorf("expected %v, got %v", expected, value)
+	}
+}
+
+type namedPredicate struct {
+	name string
+}
+
+func (p *namedPredicate) Apply(ctx common.PredicateContext) (bool, error) {
+	item, ok := ctx.Item().(map[string]interface{})
+	return ok && item["category"] == p.name, nil
+}
+
+func (p *namedPredicate) String() string {
+	return p.name
+}
+
+func TestAstCompilePassesPredicates(t *testing.T) {
+	tree := ast.Root().Field("store").Field("book").
+		Filter(&namedPredicate{name: "fiction"}).Filter(&namedPredicate{name: "reference"}).Field("title")
+	compiled, err := tree.Compile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if compiled.String() != "$['store']['book'][?][?]['title']" {
+		t.Errorf("unexpected %s", compiled.String())
+	}
+	back, err := ast.FromCompiledPath(compiled)
+	if err != nil {
+		t.Fatal(err)
+	}
+	filters := []string{}
+	_ = back.Accept(&filterCollector{names: &filters})
+	if !reflect.DeepEqual(filters, []string{"fiction", "reference"}) {
+		t.Errorf("predicates are out of order: %v", filters)
+	}
+}
+
+type filterCollector struct {
+	ast.BaseVisitor
+	names *[]string
+}
+
+func (c *filterCollector) VisitFilter(segment *ast.FilterSegment) error {
+	for _, predicate := range segment.Predicates() {
+		*c.names = append(*c.names, predicate.String())
+	}
+	return nil
+}
+
+func TestAstRewrite(t *testing.T) {
+	tree, err := ast.Parse("$.store.book[*].author")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var segments []ast.Segment
+	for _, segment := range tree.Segments() {
+		if field, ok := segment.(*ast.FieldSegment); ok && field.Names()[0] == "author" {
+			segment = ast.CreateFieldSegment("writer")
+		}
+		segments = append(segments, segment)
+	}
+	rewritten := ast.CreatePath(tree.RootToken(), segments...)
+	if rewritten.String() != "$['store']['book'][*]['writer']" {
+		t.Errorf("unexpected %s", rewritten.String())
+	}
+	if tree.String() != "$['store']['book'][*]['author']" {
+		t.Errorf("the original path changed: %s", tree.String())
+	}
+}
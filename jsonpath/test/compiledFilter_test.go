@@ -0,0 +1,141 @@
+package test
+
+import (
+	"github.com/CuiChao512/go-jsonpath/jsonpath"
+	"github.com/CuiChao512/go-jsonpath/jsonpath/common"
+	"github.com/CuiChao512/go-jsonpath/jsonpath/filter"
+	"testing"
+)
+
+var compiledFilterItems, _ = common.DefaultConfiguration().JsonProvider().Parse(`[
+	{"a": 1, "s": "x", "b": true, "n": null, "o": {"p": 0.1, "q": "2024-01-01T00:00:00Z"}, "l": [1, 2]},
+	{"a": 0.1, "s": "", "b": false, "o": {"p": "0.1"}, "l": []},
+	{"a": "1", "s": "y", "o": 3},
+	{"a": 10000000000000000000000, "s": 1},
+	"string",
+	null
+]`)
+
+var compiledFilterExpressions = []string{
+	"[?(@.a == 1)]",
+	"[?(@.a != 1)]",
+	"[?(@.a < 1)]",
+	"[?(@.a <= 0.1)]",
+	"[?(@.a > 0.1)]",
+	"[?(@.a >= 1e22)]",
+	"[?(@.a == 10000000000000000000001)]",
+	"[?(@.a == '1')]",
+	"[?(@.s == '')]",
+	"[?(@.s != '')]",
+	"[?(@.s < 'y')]",
+	"[?(@.s >= '')]",
+	"[?(@.o.p == 0.1)]",
+	"[?(@.o.p != 0.1)]",
+	"[?(@.o.q < '2025')]",
+	"[?(@.missing == 1)]",
+	"[?(@.missing != 1)]",
+	"[?(@.n == null)]",
+	"[?(@.b == true)]",
+	"[?(@.o.p)]",
+	"[?(!@.o.p)]",
+	"[?(@.n)]",
+	"[?(@.a > 0 && @.s == 'x' || @.b)]",
+	"[?(@.a > 0 && @.s == 'x' || !(@.b))]",
+	"[?(!(@.a == 1))]",
+	"[?(@.a > 0 && !(@.s == 'x' || @.b))]",
+	"[?(@.l.length() > 1)]",
+	"[?(@.a in [1, '1'])]",
+	"[?(@.a + 1 > 1.5)]",
+}
+
+func TestCompiledFilterAppliesLikeTheExpressionTree(t *testing.T) {
+	items, _ := common.DefaultConfiguration().JsonProvider().ToArray(compiledFilterItems)
+	configurations := []*common.Configuration{
+		common.DefaultConfiguration(),
+		common.DefaultConfiguration().SetOptions(common.OPTION_SUPPRESS_EXCEPTIONS),
+		common.DefaultConfiguration().SetOptions(common.OPTION_DEFAULT_PATH_LEAF_TO_NULL),
+		common.DefaultConfiguration().SetOptions(common.OPTION_REQUIRE_PROPERTIES),
+	}
+	for _, expression := range compiledFilterExpressions {
+		compiled, err := filter.Compile(expression)
+		if err != nil {
+			t.Fatalf("%s: %v", expression, err)
+		}
+		for _, configuration := range configurations {
+			for _, item := range items {
+				ctx := common.CreatePredicateContextImpl(item, compiledFilterItems, configuration, map[common.Path]interface{}{})
+				expected, expectedErr := compiled.Expression().Apply(ctx)
+				result, err := compiled.Apply(ctx)
+				if result != expected || (err == nil) != (expectedErr == nil) {
+					t.Errorf("%s %v on %v: expected %t %v, got %t %v", expression, configuration.Options(), item, expected, expectedErr, result, err)
+				}
+			}
+		}
+	}
+}
+
+// benchmarkFilters are the filters of the filter evaluation fixtures that the filter compiler reads back
+func benchmarkFilters(b *testing.B) []*filter.CompiledFilter {
+	var filters []*filter.CompiledFilter
+	for _, data := range testMetaData {
+		for _, row := range data {
+			expression := row.Expression
+			if row.Operator != 0 {
+				criteria, err := jsonpath.WhereString(row.Key)
+				if err != nil {
+					b.Fatal(err)
+				}
+				switch row.Operator {
+				case eq:
+					criteria, err = criteria.Eq(row.Value)
+				case ne:
+					criteria, err = criteria.Ne(row.Value)
+				case lt:
+					criteria, err = criteria.Lt(row.Value)
+				case lte:
+					criteria, err = criteria.Lte(row.Value)
+				case gt:
+					criteria, err = criteria.Gt(row.Value)
+				case gte:
+					criteria, err = criteria.Gte(row.Value)
+				case exists:
+					expected, _ := row.Value.(bool)
+					criteria, err = criteria.Exists(expected)
+				default:
+					continue
+				}
+				if err != nil {
+					continue
+				}
+				expression = "[?(" + criteria.String() + ")]"
+			}
+			compiled, err := filter.Compile(expression)
+			if err == nil {
+				filters = append(filters, compiled)
+			}
+		}
+	}
+	return filters
+}
+
+func BenchmarkFilterExpressionTree(b *testing.B) {
+	filters := benchmarkFilters(b)
+	ctx := createPredicateContext(FilterTestJson)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, f := range filters {
+			_, _ = f.Expression().Apply(ctx)
+		}
+	}
+}
+
+func BenchmarkFilterCompiled(b *testing.B) {
+	filters := benchmarkFilters(b)
+	ctx := createPredicateContext(FilterTestJson)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, f := range filters {
+			_, _ = f.Apply(ctx)
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package test
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"github.com/CuiChao512/go-jsonpath/jsonpath"
+	"github.com/CuiChao512/go-jsonpath/jsonpath/common"
+	"os"
+	"strings"
+	"testing"
+)
+
+// testdata/cts.json is a hand-transcribed subset of the cases of
+// https://github.com/jsonpath-standard/jsonpath-compliance-test-suite in its native format, not the full suite. Passing
+// it does not mean full compliance, replace the file with the upstream cts.json to run every case.
+// Run with -v or -cts.report=<file> to get the per-case report.
+var ctsReport = flag.String("cts.report", "", "write the per-case compliance test suite report to this file")
+
+const ctsFile = "testdata/cts.json"
+
+type ctsCase struct {
+	Name            string          `json:"name"`
+	Selector        string          `json:"selector"`
+	Document        interface{}     `json:"document"`
+	Result          []interface{}   `json:"result"`
+	Results         [][]interface{} `json:"results"`
+	ResultPaths     []string        `json:"result_paths"`
+	ResultsPaths    [][]string      `json:"results_paths"`
+	InvalidSelector bool            `json:"invalid_selector"`
+	Tags            []string        `json:"tags"`
+}
+
+type ctsSuite struct {
+	Description string     `json:"description"`
+	Tests       []*ctsCase `json:"tests"`
+}
+
+type ctsOutcome string
+
+const (
+	ctsPass ctsOutcome = "PASS"
+	ctsFail ctsOutcome = "FAIL"
+	ctsSkip ctsOutcome = "SKIP"
+)
+
+type ctsMode struct {
+	name    string
+	options []common.Option
+	// strict modes must pass every case, the others only report their conformance
+	strict bool
+	skip   func(c *ctsCase) string
+}
+
+var ctsModes = []*ctsMode{
+	{
+		name:    "jayway",
+		options: []common.Option{common.OPTION_ALWAYS_RETURN_LIST, common.OPTION_SUPPRESS_EXCEPTIONS},
+		skip: func(c *ctsCase) string {
+			if c.Document == nil && !c.InvalidSelector {
+				return "a null document can not be parsed"
+			}
+			return ""
+		},
+	},
+	{
+		name:    "rfc9535",
+		options: []common.Option{common.OPTION_RFC9535},
+		strict:  true,
+	},
+}
+
+func loadCtsSuite(t *testing.T) *ctsSuite {
+	data, err := os.ReadFile(ctsFile)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	suite := &ctsSuite{}
+	if err := json.Unmarshal(data, suite); err != nil {
+		t.Fatalf(err.Error())
+	}
+	return suite
+}
+
+func ctsRead(mode *ctsMode, c *ctsCase, options ...common.Option) (result interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	conf := common.DefaultConfiguration().AddOptions(mode.options...).AddOptions(options...)
+	document := c.Document
+	if c.InvalidSelector {
+		document = map[string]interface{}{}
+	}
+	documentContext, err := jsonpath.CreateParseContextImplByConfiguration(conf).ParseAny(document)
+	if err != nil {
+		return nil, err
+	}
+	return documentContext.Read(c.Selector)
+}
+
+func ctsEquals(actual interface{}, expected interface{}) bool {
+	a, err := json.Marshal(actual)
+	if err != nil {
+		return false
+	}
+	e, err := json.Marshal(expected)
+	return err == nil && string(a) == string(e)
+}
+
+func ctsRun(mode *ctsMode, c *ctsCase) (ctsOutcome, string) {
+	if mode.skip != nil {
+		if reason := mode.skip(c); reason != "" {
+			return ctsSkip, reason
+		}
+	}
+	values, err := ctsRead(mode, c)
+	if c.InvalidSelector {
+		if err == nil {
+			return ctsFail, "invalid selector was accepted"
+		}
+		return ctsPass, ""
+	}
+	if err != nil {
+		return ctsFail, err.Error()
+	}
+	expected := c.Results
+	if expected == nil {
+		expected = [][]interface{}{c.Result}
+	}
+	matched := -1
+	for i, e := range expected {
+		if ctsEquals(values, e) {
+			matched = i
+			break
+		}
+	}
+	if matched < 0 {
+		return ctsFail, fmt.Sprintf("got %s", common.UtilsToString(values))
+	}
+	expectedPaths := c.ResultsPaths
+	if expectedPaths == nil && c.ResultPaths != nil {
+		expectedPaths = [][]string{c.ResultPaths}
+	}
+	if expectedPaths == nil {
+		return ctsPass, ""
+	}
+	paths, err := ctsRead(mode, c, common.OPTION_AS_PATH_LIST)
+	if err != nil {
+		return ctsFail, err.Error()
+	}
+	if !ctsEquals(paths, expectedPaths[matched]) {
+		return ctsFail, fmt.Sprintf("got paths %s", common.UtilsToString(paths))
+	}
+	return ctsPass, ""
+}
+
+func Test_compliance_test_suite(t *testing.T) {
+	suite := loadCtsSuite(t)
+	report := &strings.Builder{}
+	for _, mode := range ctsModes {
+		counts := map[ctsOutcome]int{}
+		t.Run(mode.name, func(t *testing.T) {
+			for _, c := range suite.Tests {
+				outcome, reason := ctsRun(mode, c)
+				counts[outcome]++
+				line := fmt.Sprintf("%s %s: %s", outcome, mode.name, c.Name)
+				if reason != "" {
+					line += " (" + reason + ")"
+				}
+				report.WriteString(line + "\n")
+				if outcome == ctsFail && mode.strict {
+					t.Errorf("%s [%s]: %s", c.Name, c.Selector, reason)
+				} else {
+					t.Log(line)
+				}
+			}
+		})
+		summary := fmt.Sprintf("%s: %d passed, %d failed, %d skipped of %d", mode.name,
+			counts[ctsPass], counts[ctsFail], counts[ctsSkip], len(suite.Tests))
+		report.WriteString(summary + "\n")
+		t.Log(summary)
+	}
+	if *ctsReport != "" {
+		if err := os.WriteFile(*ctsReport, []byte(report.String()), 0644); err != nil {
+			t.Errorf(err.Error())
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package test
+
+import (
+	"github.com/CuiChao512/go-jsonpath/jsonpath"
+	"github.com/CuiChao512/go-jsonpath/jsonpath/common"
+	"reflect"
+	"testing"
+)
+
+var definitePathDocument = `{"a": {"b": [0, 1, 2, {"c": "three", "n": null}], "s": "str"}, "list": []}`
+
+var definitePaths = []string{
+	"$",
+	"$.a",
+	"$.a.b[3].c",
+	"$.a.b[-1].c",
+	"$.a.b[3].n",
+	"$.a.b[0]",
+	"$['a']['s']",
+	"$.a.b[3].missing",
+	"$.a.b[9].c",
+	"$.a.b[-9]",
+	"$.a.s.c",
+	"$.list[0]",
+}
+
+// continueListener makes the read evaluate the path, a path with evaluation listeners is not read with direct lookups
+type continueListener struct{}
+
+func (continueListener) ResultFound(found common.FoundResult) common.EvaluationContinuation {
+	return common.CONTINUE
+}
+
+func TestDefinitePathReadsLikeEvaluation(t *testing.T) {
+	optionSets := [][]common.Option{
+		nil,
+		{common.OPTION_AS_PATH_LIST},
+		{common.OPTION_ALWAYS_RETURN_LIST},
+		{common.OPTION_SUPPRESS_EXCEPTIONS},
+		{common.OPTION_DEFAULT_PATH_LEAF_TO_NULL},
+		{common.OPTION_AS_PATH_LIST, common.OPTION_SUPPRESS_EXCEPTIONS},
+	}
+	for _, options := range optionSets {
+		configuration := common.DefaultConfiguration().SetOptions(options...)
+		fast, err := jsonpath.CreateParseContextImplByConfiguration(configuration).ParseString(definitePathDocument)
+		if err != nil {
+			t.Fatal(err)
+		}
+		evaluated, err := fast.WithListeners(continueListener{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, path := range definitePaths {
+			expected, expectedErr := evaluated.Read(path)
+			result, err := fast.Read(path)
+			if !reflect.DeepEqual(result, expected) || (err == nil) != (expectedErr == nil) ||
+				err != nil && err.Error() != expectedErr.Error() {
+				t.Errorf("%s %v: expected %v %v, got %v %v", path, options, expected, expectedErr, result, err)
+			}
+		}
+	}
+}
+
+func TestDefinitePathDoesNotAllocate(t *testing.T) {
+	ctx, err := getParseContextUsingDefaultConf().ParseString(definitePathDocument)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path, err := jsonpath.CreateJsonpathByStringAndPredicates("$.a.b[3].c", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	allocs := testing.AllocsPerRun(100, func() {
+		_, _ = ctx.ReadJsonpath(path)
+	})
+	if allocs != 0 {
+		t.Errorf("expected no allocations, got %v", allocs)
+	}
+}
+
+func BenchmarkDefinitePath(b *testing.B) {
+	ctx, err := getParseContextUsingDefaultConf().ParseString(definitePathDocument)
+	if err != nil {
+		b.Fatal(err)
+	}
+	path, err := jsonpath.CreateJsonpathByStringAndPredicates("$.a.b[3].c", nil)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = ctx.ReadJsonpath(path)
+	}
+}
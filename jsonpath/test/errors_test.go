@@ -0,0 +1,108 @@
+package test
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/CuiChao512/go-jsonpath/jsonpath"
+	"github.com/CuiChao512/go-jsonpath/jsonpath/common"
+	"github.com/CuiChao512/go-jsonpath/jsonpath/filter"
+	"regexp/syntax"
+	"testing"
+)
+
+type errorCodeTestData struct {
+	path     string
+	sentinel error
+	code     common.ErrorCode
+	location string
+}
+
+var errorCodeTestDatas = []errorCodeTestData{
+	{path: "$.a.x", sentinel: common.ErrMissingProperty, code: common.CodeMissingProperty, location: "$['a']['x']"},
+	{path: "$.a.b.c", sentinel: common.ErrNotAnObject, code: common.CodeNotAnObject, location: "$['a']['b']"},
+	{path: "$.a[0]", sentinel: common.ErrNotAnArray, code: common.CodeNotAnArray, location: "$['a']"},
+}
+
+func TestErrorCodes(t *testing.T) {
+	conf := common.DefaultConfiguration().AddOptions(common.OPTION_REQUIRE_PROPERTIES)
+	ctx, err := jsonpath.CreateParseContextImplByConfiguration(conf).ParseString(`{"a": {"b": 1}}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, data := range errorCodeTestDatas {
+		_, err := ctx.Read(data.path)
+		if !errors.Is(err, data.sentinel) || !errors.Is(err, common.ErrPathNotFound) {
+			t.Errorf("%s: expected %v, got %v", data.path, data.sentinel, err)
+			continue
+		}
+		if common.ErrorCodeOf(err) != data.code {
+			t.Errorf("%s: expected code %s, got %s", data.path, data.code, common.ErrorCodeOf(err))
+		}
+		var notFound *common.PathNotFoundError
+		if !errors.As(err, &notFound) || notFound.Location != data.location || notFound.Path == "" {
+			t.Errorf("%s: unexpected %+v", data.path, err)
+		}
+		if errors.Is(err, common.ErrInvalidPath) {
+			t.Errorf("%s: a PathNotFoundError should not be an invalid path", data.path)
+		}
+	}
+}
+
+func TestInvalidJsonErrorKeepsCause(t *testing.T) {
+	_, err := getParseContextUsingDefaultConf().ParseString(`{"a": `)
+	if !errors.Is(err, common.ErrInvalidJson) {
+		t.Fatalf("expected invalid json, got %v", err)
+	}
+	var syntaxError *json.SyntaxError
+	if !errors.As(err, &syntaxError) {
+		t.Errorf("expected the json.SyntaxError as cause, got %v", err)
+	}
+}
+
+func TestInvalidPatternErrorKeepsCause(t *testing.T) {
+	_, err := filter.PathCompile("$[?(@.a =~ /a**/)]")
+	if !errors.Is(err, common.ErrPathSyntax) || !errors.Is(err, common.ErrInvalidPattern) {
+		t.Fatalf("expected an invalid pattern syntax error, got %v", err)
+	}
+	var regexpError *syntax.Error
+	if !errors.As(err, &regexpError) {
+		t.Errorf("expected the regexp error as cause, got %v", err)
+	}
+	if common.ErrorCodeOf(err) != common.CodePathSyntax {
+		t.Errorf("expected code %s, got %s", common.CodePathSyntax, common.ErrorCodeOf(err))
+	}
+
+	ctx, err := getParseContextUsingDefaultConf().ParseString(`{"a": "b"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = ctx.Read("$.a.replace('a**', 'x')")
+	if !errors.As(err, &regexpError) || !errors.Is(err, common.ErrInvalidPath) {
+		t.Errorf("expected the regexp error as cause, got %v", err)
+	}
+}
+
+func TestErrorWithPathCopiesError(t *testing.T) {
+	original := &common.PathNotFoundError{Message: "Missing property",
+		ErrorDetails: common.ErrorDetails{Location: "$['a']", ErrorCode: common.CodeMissingProperty}}
+	err := common.ErrorWithPath(original, "$.a")
+	withPath, ok := err.(*common.PathNotFoundError)
+	if !ok || withPath == original {
+		t.Fatalf("expected a copy of the error, got %v", err)
+	}
+	if withPath.Path != "$.a" || withPath.Location != "$['a']" || withPath.Code() != common.CodeMissingProperty {
+		t.Errorf("unexpected %+v", *withPath)
+	}
+	if original.Path != "" {
+		t.Errorf("the original error was changed: %+v", *original)
+	}
+	if common.ErrorWithPath(withPath, "$.b") != withPath {
+		t.Errorf("an error with a path should be returned as it is")
+	}
+
+	cause := errors.New("failed")
+	wrapped := common.ErrorWithPath(cause, "$.a")
+	if !errors.Is(wrapped, common.ErrJsonPath) || !errors.Is(wrapped, cause) {
+		t.Errorf("expected a JsonPathError caused by the error, got %v", wrapped)
+	}
+}
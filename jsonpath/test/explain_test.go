@@ -0,0 +1,137 @@
+package test
+
+import (
+	"encoding/json"
+	"github.com/CuiChao512/go-jsonpath/jsonpath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var explainDocument = `{"items": [{"price": 5, "name": "a"}, {"price": 15, "name": "b", "tag": true}]}`
+
+func TestExplainFilter(t *testing.T) {
+	explanation, err := jsonpath.Explain("$.items[?(@.price < 10 && (@.name == 'b' || @.tag))].name", explainDocument)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := strings.Join([]string{
+		"$['items'][?]['name']: 0 results",
+		"$",
+		"  token $",
+		"  token ['items']",
+		"  $['items']",
+		"    token [?]",
+		"    $['items'][0]",
+		"      filter [?(@['price'] < 10 && (@['name'] == 'b' || @['tag']))]: false",
+		"        &&: false",
+		"          @['price'] < 10: true (left 5, right 10)",
+		"          ||: false",
+		"            @['name'] == 'b': false (left \"a\", right \"b\")",
+		"            @['tag']: false (left false)",
+		"    $['items'][1]",
+		"      filter [?(@['price'] < 10 && (@['name'] == 'b' || @['tag']))]: false",
+		"        &&: false",
+		"          @['price'] < 10: false (left 15, right 10)",
+	}, "\n")
+	if explanation.String() != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, explanation.String())
+	}
+}
+
+func TestExplainNegatedFilter(t *testing.T) {
+	explanation, err := jsonpath.Explain("$.items[?(!(@.price > 10 && @.tag))].name", explainDocument)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := strings.Join([]string{
+		"$['items'][?]['name']: 1 results",
+		"$",
+		"  token $",
+		"  token ['items']",
+		"  $['items']",
+		"    token [?]",
+		"    $['items'][0]",
+		"      filter [?(!(@['price'] > 10 && @['tag']))]: true",
+		"        !: true",
+		"          &&: false",
+		"            @['price'] > 10: false (left 5, right 10)",
+		"      token ['name']",
+		"      $['items'][0]['name'] (result)",
+		"    $['items'][1]",
+		"      filter [?(!(@['price'] > 10 && @['tag']))]: false",
+		"        !: false",
+		"          &&: true",
+		"            @['price'] > 10: true (left 15, right 10)",
+		"            @['tag']: true (left true)",
+	}, "\n")
+	if explanation.String() != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, explanation.String())
+	}
+}
+
+func TestExplainMissingProperty(t *testing.T) {
+	explanation, err := jsonpath.Explain("$.items[*].missing", explainDocument)
+	if err != nil {
+		t.Fatal(err)
+	}
+	items := explanation.Root.Children[0]
+	if len(items.Children) != 2 {
+		t.Fatalf("expected both items, got %v", explanation)
+	}
+	for _, item := range items.Children {
+		if len(item.Children) != 1 || !item.Children[0].Missing {
+			t.Errorf("expected the property of %s to be missing", item.Path)
+		}
+	}
+
+	explanation, err = jsonpath.Explain("$.nope.x", explainDocument)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if explanation.Error != "Missing property in path $['nope']" {
+		t.Errorf("unexpected error %q", explanation.Error)
+	}
+}
+
+func TestExplainJson(t *testing.T) {
+	explanation, err := jsonpath.Explain("$.items[?(@.price < 10)].name", explainDocument)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rendered, err := explanation.ToJson()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var parsed struct {
+		Results []string
+		Root    struct {
+			Children []struct {
+				Children []struct {
+					Path    string
+					Filters []struct {
+						Result     bool
+						Expression struct {
+							Left  struct{ Value interface{} }
+							Right struct{ Value interface{} }
+						}
+					}
+				}
+			}
+		}
+	}
+	if err = json.Unmarshal([]byte(rendered), &parsed); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(parsed.Results, []string{"$['items'][0]['name']"}) {
+		t.Errorf("unexpected results %v", parsed.Results)
+	}
+	items := parsed.Root.Children[0].Children
+	if len(items) != 2 || items[1].Path != "$['items'][1]" || len(items[1].Filters) != 1 {
+		t.Fatalf("unexpected items in %s", rendered)
+	}
+	filter := items[1].Filters[0]
+	if filter.Result || filter.Expression.Left.Value != 15.0 || filter.Expression.Right.Value != 10.0 {
+		t.Errorf("unexpected filter explanation in %s", rendered)
+	}
+}
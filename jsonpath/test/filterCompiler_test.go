@@ -64,6 +64,17 @@ var validFilterTestDataSlice = []validFilterTestData{
 		FilterString:           "[?(!@.foo)]",
 		FilterToStringExpected: "[?(!@['foo'])]",
 	},
+	//arithmetic
+	{FilterString: "[?(@.price * @.qty > 100)]", FilterToStringExpected: "[?(@['price'] * @['qty'] > 100)]"},
+	{FilterString: "[?(@.a + @.b * 2 >= 1)]", FilterToStringExpected: "[?(@['a'] + @['b'] * 2 >= 1)]"},
+	{FilterString: "[?((@.a + @.b) * 2 > 1)]", FilterToStringExpected: "[?((@['a'] + @['b']) * 2 > 1)]"},
+	{FilterString: "[?(@.a - (@.b - @.c) > 1)]", FilterToStringExpected: "[?(@['a'] - (@['b'] - @['c']) > 1)]"},
+	{FilterString: "[?(-(@.a + 1) < -1)]", FilterToStringExpected: "[?(-(@['a'] + 1) < -1)]"},
+	{FilterString: "[?(@.total % 2 == 0 && @.a / 2 == 1)]", FilterToStringExpected: "[?(@['total'] % 2 == 0 && @['a'] / 2 == 1)]"},
+	//function without a path
+	{FilterString: "[?(@.expiresAt < now())]", FilterToStringExpected: "[?(@['expiresAt'] < $.now())]"},
+	{FilterString: "[?(now().dateAdd('24h') > @.at)]", FilterToStringExpected: "[?($.now().dateAdd(...) > @['at'])]"},
+	{FilterString: "[?(@.id == $userId && @.role in $roles)]", FilterToStringExpected: "[?(@['id'] == $userId && @['role'] in $roles)]"},
 }
 
 func Test_valid_filters_compile(t *testing.T) {
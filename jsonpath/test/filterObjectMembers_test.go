@@ -0,0 +1,97 @@
+package test
+
+import (
+	"github.com/CuiChao512/go-jsonpath/jsonpath"
+	"github.com/CuiChao512/go-jsonpath/jsonpath/common"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+var objectMembersTestDocument = "{\n" +
+	"  \"env\": {\"FEATURE_A\": true, \"FEATURE_B\": false, \"HOME\": \"/root\"},\n" +
+	"  \"users\": {\n" +
+	"    \"u1\": {\"name\": \"ann\", \"age\": 25, \"scores\": [1, 9]},\n" +
+	"    \"u2\": {\"name\": \"bob\", \"age\": 41, \"scores\": [7]}\n" +
+	"  }\n" +
+	"}"
+
+type objectMembersTestData struct {
+	path   string
+	expect []interface{}
+}
+
+var objectMembersTestDatas = []objectMembersTestData{
+	{path: "$.users[?(@.age > 30)].name", expect: []interface{}{"bob"}},
+	{path: "$.users[?(@.age > 30)]~", expect: []interface{}{"u2"}},
+	{path: "$.env[?(@property =~ /^FEATURE_/)]~", expect: []interface{}{"FEATURE_A", "FEATURE_B"}},
+	{path: "$.env[?(@ == true)]~", expect: []interface{}{"FEATURE_A"}},
+	{path: "$.users.u1.scores[?(@ > 5)]", expect: []interface{}{float64(9)}},
+	{path: "$..[?(@ > 8)]", expect: []interface{}{float64(25), float64(41), float64(9)}},
+	{path: "$..[?(@.name)]~", expect: []interface{}{"u1", "u2"}},
+	{path: "$.users[?(@parentProperty == 'users' && @.name == 'ann')].age", expect: []interface{}{float64(25)}},
+}
+
+func sortedStrings(values []interface{}) []string {
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		result = append(result, common.UtilsToString(value))
+	}
+	sort.Strings(result)
+	return result
+}
+
+func Test_filter_object_members(t *testing.T) {
+	conf := common.DefaultConfiguration().AddOptions(common.OPTION_FILTER_OBJECT_MEMBERS)
+	for _, data := range objectMembersTestDatas {
+		documentContext, err := jsonpath.CreateParseContextImplByConfiguration(conf).ParseString(objectMembersTestDocument)
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+		result, err := documentContext.Read(data.path)
+		if err != nil {
+			t.Errorf("%s: %s", data.path, err.Error())
+			continue
+		}
+		results, ok := result.([]interface{})
+		if !ok {
+			t.Errorf("%s: expected a list, got %v", data.path, result)
+			continue
+		}
+		// object members are not ordered
+		if !reflect.DeepEqual(sortedStrings(results), sortedStrings(data.expect)) {
+			t.Errorf("%s: expected %v, got %v", data.path, data.expect, result)
+		}
+	}
+}
+
+func Test_filter_object_members_paths(t *testing.T) {
+	conf := common.DefaultConfiguration().AddOptions(common.OPTION_FILTER_OBJECT_MEMBERS, common.OPTION_AS_PATH_LIST)
+	documentContext, err := jsonpath.CreateParseContextImplByConfiguration(conf).ParseString(objectMembersTestDocument)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	result, err := documentContext.Read("$.users[?(@.age > 30)]")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	expect := []interface{}{"$['users']['u2']"}
+	if !reflect.DeepEqual(result, expect) {
+		t.Errorf("expected %v, got %v", expect, result)
+	}
+}
+
+func Test_filter_object_itself_by_default(t *testing.T) {
+	documentContext, err := jsonpath.ParseString(objectMembersTestDocument)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	result, err := documentContext.Read("$.users[?(@.u1)]~")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	expect := []interface{}{"users"}
+	if !reflect.DeepEqual(result, expect) {
+		t.Errorf("expected %v, got %v", expect, result)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/CuiChao512/go-jsonpath/jsonpath"
 	"github.com/CuiChao512/go-jsonpath/jsonpath/common"
 	"reflect"
+	"testing"
 )
 
 const (
@@ -41,3 +42,42 @@ func verifyTextFunction(conf *common.Configuration, pathExpr string, expectedVal
 func verifyTextAndNumberFunction(conf *common.Configuration, pathExpr string, expectedValue interface{}) (bool, error) {
 	return verifyFunction(conf, pathExpr, TEXT_AND_NUMBER_SERIES, expectedValue)
 }
+
+// functionTestData is a path and the value it reads from a series
+type functionTestData struct {
+	path   string
+	expect interface{}
+}
+
+// functionErrorTestData is a path that fails on a series with the error of the code and the message
+type functionErrorTestData struct {
+	path    string
+	code    common.ErrorCode
+	message string
+}
+
+func verifyFunctions(t *testing.T, conf *common.Configuration, json string, datas []functionTestData) {
+	for _, data := range datas {
+		result, err := verifyFunction(conf, data.path, json, data.expect)
+		if err != nil {
+			t.Errorf("%s: %s", data.path, err)
+		} else if !result {
+			t.Errorf("%s: not expected", data.path)
+		}
+	}
+}
+
+func verifyFunctionErrors(t *testing.T, conf *common.Configuration, json string, datas []functionErrorTestData) {
+	for _, data := range datas {
+		_, err := verifyFunction(conf, data.path, json, nil)
+		assertFunctionError(t, data, err)
+	}
+}
+
+func assertFunctionError(t *testing.T, data functionErrorTestData, err error) {
+	if err == nil {
+		t.Errorf("%s: expected an error", data.path)
+	} else if common.ErrorCodeOf(err) != data.code || err.Error() != data.message {
+		t.Errorf("%s: expected %s %q, got %s %q", data.path, data.code, data.message, common.ErrorCodeOf(err), err.Error())
+	}
+}
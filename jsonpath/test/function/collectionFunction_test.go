@@ -0,0 +1,48 @@
+package function
+
+import (
+	"github.com/CuiChao512/go-jsonpath/jsonpath/common"
+	"testing"
+)
+
+const COLLECTION_SERIES = "{\"events\": [{\"type\": \"a\"}, {\"type\": \"b\"}, {\"type\": \"a\"}], \"scores\": [3, 1, 2], " +
+	"\"items\": [{\"n\": \"x\", \"price\": 5}, {\"n\": \"y\", \"price\": 9}, {\"n\": \"z\"}, {\"n\": \"w\", \"price\": 1}], " +
+	"\"matrix\": [[1, 2], [3, [4]]], \"store\": {\"book\": [{\"price\": 8}, {\"price\": 12}], \"bicycle\": {\"price\": 20}}}"
+
+var collectionFunctionTestDatas = []functionTestData{
+	{path: "$.events[*].type.distinct()", expect: []interface{}{"a", "b"}},
+	{path: "$.events.distinct()", expect: []interface{}{map[string]interface{}{"type": "a"}, map[string]interface{}{"type": "b"}}},
+	{path: "$.events[*].type.distinct().join()", expect: "a,b"},
+	{path: "$.scores.sort()", expect: []interface{}{float64(1), float64(2), float64(3)}},
+	{path: "$.scores.sort('desc')", expect: []interface{}{float64(3), float64(2), float64(1)}},
+	{path: "$.items.sort(@.price, 'desc')[*].n", expect: []interface{}{"y", "x", "w", "z"}},
+	{path: "$.items.sort(@.price)[*].n", expect: []interface{}{"w", "x", "y", "z"}},
+	{path: "$.items[*].price.sort().first()", expect: float64(1)},
+	{path: "$.matrix.flatten()", expect: []interface{}{float64(1), float64(2), float64(3), []interface{}{float64(4)}}},
+	{path: "$.matrix.flatten(2)", expect: []interface{}{float64(1), float64(2), float64(3), float64(4)}},
+	{path: "$.scores.first()", expect: float64(3)},
+	{path: "$.scores.last()", expect: float64(2)},
+	{path: "$.scores.index(1)", expect: float64(1)},
+	{path: "$.scores.index(-3)", expect: float64(3)},
+	{path: "$.scores.reverse()", expect: []interface{}{float64(2), float64(1), float64(3)}},
+	{path: "$..price.sort()", expect: []interface{}{float64(1), float64(5), float64(8), float64(9), float64(12), float64(20)}},
+	{path: "$..price.sum()", expect: float64(55)},
+}
+
+var collectionFunctionErrorTestDatas = []functionErrorTestData{
+	{path: "$.scores.index(7)", code: common.CodeJsonPath,
+		message: "Function index() found no item at index 7 of an array of length 3"},
+	{path: "$.store.bicycle.first()", code: common.CodeJsonPath,
+		message: "Function first() expects an array, got: map[price:20]"},
+	{path: "$.scores.sort('up')", code: common.CodeInvalidPath, message: "Function sort() expects 'asc' or 'desc', got: up"},
+	{path: "$.matrix.flatten('x')", code: common.CodeInvalidPath,
+		message: "Function flatten() expects an integer as parameter 1"},
+}
+
+func TestCollectionFunctions(t *testing.T) {
+	verifyFunctions(t, common.DefaultConfiguration(), COLLECTION_SERIES, collectionFunctionTestDatas)
+}
+
+func TestCollectionFunctionsNegative(t *testing.T) {
+	verifyFunctionErrors(t, common.DefaultConfiguration(), COLLECTION_SERIES, collectionFunctionErrorTestDatas)
+}
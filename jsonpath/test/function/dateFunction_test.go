@@ -0,0 +1,58 @@
+package function
+
+import (
+	"github.com/CuiChao512/go-jsonpath/jsonpath/common"
+	"testing"
+	"time"
+)
+
+type fixedClock struct{}
+
+func (fixedClock) Now() time.Time {
+	return time.Date(2024, 3, 10, 12, 30, 0, 0, time.UTC)
+}
+
+const DATE_SERIES = "{\"tokens\": [{\"id\": \"a\", \"expiresAt\": \"2024-03-09T00:00:00Z\"}, " +
+	"{\"id\": \"b\", \"expiresAt\": \"2024-03-11T08:00:00+02:00\"}, {\"id\": \"c\", \"expiresAt\": \"2024-03-10T14:00:00+02:00\"}], " +
+	"\"day\": \"10/03/2024\", \"ms\": 1710073800000}"
+
+var dateFunctionTestDatas = []functionTestData{
+	{path: "$.now()", expect: "2024-03-10T12:30:00Z"},
+	{path: "$.now().formatDate()", expect: "2024-03-10T12:30:00Z"},
+	{path: "$.day.parseDate('02/01/2006')", expect: "2024-03-10T00:00:00Z"},
+	{path: "$.tokens[*].expiresAt.dateAdd('1d')", expect: []interface{}{"2024-03-10T00:00:00Z", "2024-03-12T08:00:00+02:00", "2024-03-11T14:00:00+02:00"}},
+	{path: "$.tokens[1].expiresAt.truncate('day')", expect: "2024-03-11T00:00:00+02:00"},
+	{path: "$.now().epochMillis()", expect: int64(1710073800000)},
+	{path: "$.ms.formatDate()", expect: "2024-03-10T12:30:00Z"},
+	{path: "$.day.parseDate('02/01/2006').formatDate('2006-01-02')", expect: "2024-03-10"},
+	{path: "$.tokens[0].expiresAt.dateAdd('7d').formatDate()", expect: "2024-03-16T00:00:00Z"},
+	{path: "$.tokens[0].expiresAt.dateAdd('-90m').formatDate()", expect: "2024-03-08T22:30:00Z"},
+	{path: "$.tokens[*].expiresAt.dateDiff($.tokens[0].expiresAt, 'hours')", expect: []interface{}{float64(0), float64(54), float64(36)}},
+	{path: "$.tokens[1].expiresAt.truncate('day').formatDate()", expect: "2024-03-11T00:00:00+02:00"},
+	{path: "$.tokens[1].expiresAt.truncate('month').formatDate()", expect: "2024-03-01T00:00:00+02:00"},
+	{path: "$.tokens[?(@.expiresAt < now())].id", expect: []interface{}{"a", "c"}},
+	{path: "$.tokens[?(@.expiresAt > now().dateAdd('12h'))].id", expect: []interface{}{"b"}},
+	{path: "$.tokens[?(@.expiresAt.truncate('day') == '2024-03-10T00:00:00+02:00')].id", expect: []interface{}{"c"}},
+	{path: "$.tokens[?(@.expiresAt.dateAdd('1h') < now().dateAdd('2h'))].id", expect: []interface{}{"a", "c"}},
+	{path: "$.tokens[?(@.expiresAt.dateDiff(now(), 'days') > 0.5)].id", expect: []interface{}{"b"}},
+}
+
+var dateFunctionErrorTestDatas = []functionErrorTestData{
+	{path: "$.tokens[0].expiresAt.dateAdd('soon')", code: common.CodeInvalidPath,
+		message: "Function dateAdd() expects a duration like '24h' or '7d', got: soon"},
+	{path: "$.day.dateAdd('1d')", code: common.CodeJsonPath, message: "Function dateAdd() expects a date, got: 10/03/2024"},
+	{path: "$.day.parseDate('2006-01-02')", code: common.CodeJsonPath,
+		message: "Function parseDate() can not parse: 10/03/2024"},
+	{path: "$.tokens[0].expiresAt.dateDiff(now(), 'weeks')", code: common.CodeInvalidPath,
+		message: "Function dateDiff() does not know the unit: weeks"},
+	{path: "$.tokens[0].expiresAt.truncate('eon')", code: common.CodeInvalidPath,
+		message: "Function truncate() does not know the unit: eon"},
+}
+
+func TestDateFunctions(t *testing.T) {
+	verifyFunctions(t, common.DefaultConfiguration().SetClock(fixedClock{}), DATE_SERIES, dateFunctionTestDatas)
+}
+
+func TestDateFunctionsNegative(t *testing.T) {
+	verifyFunctionErrors(t, common.DefaultConfiguration().SetClock(fixedClock{}), DATE_SERIES, dateFunctionErrorTestDatas)
+}
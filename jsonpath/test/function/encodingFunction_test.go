@@ -0,0 +1,55 @@
+package function
+
+import (
+	"github.com/CuiChao512/go-jsonpath/jsonpath/common"
+	"testing"
+)
+
+const ENCODING_SERIES = "{\"users\": [{\"email\": \"joe@example.com\", \"id\": 42}], \"encoded\": \"am9lQGV4YW1wbGUuY29t\", " +
+	"\"query\": \"a b&c=d/é\", \"broken\": \"%%%\"}"
+
+var encodingFunctionTestDatas = []functionTestData{
+	{path: "$.users[0].email.sha256()", expect: "2481f36dfc515ca76451aaadf1399026942a01ee50c6d0a61988b43cef039bc2"},
+	{path: "$.users[0].id.sha256()", expect: "73475cb40a568e8da8a045ced110137e159f890ac4da883b6b17dc651b3a8049"},
+	{path: "$.users[0].email.md5()", expect: "f5b8fb60c6116331da07c65b96a8a1d1"},
+	{path: "$.users[*].email.hmac('pii')", expect: []interface{}{"fa37c3f53c8f0e0bfe9a7c60a4bb926bba5f7153fa555ad281ced46cdad21526"}},
+	{path: "$.users[0].email.base64Encode()", expect: "am9lQGV4YW1wbGUuY29t"},
+	{path: "$.encoded.base64Decode()", expect: "joe@example.com"},
+	{path: "$.query.urlEncode()", expect: "a+b%26c%3Dd%2F%C3%A9"},
+	{path: "$.users[?(@.email.md5() == 'f5b8fb60c6116331da07c65b96a8a1d1')].id", expect: []interface{}{float64(42)}},
+}
+
+var encodingFunctionErrorTestDatas = []functionErrorTestData{
+	{path: "$.users[0].email.hmac('other')", code: common.CodeJsonPath, message: "Function hmac() found no key named: other"},
+	{path: "$.broken.base64Decode()", code: common.CodeJsonPath, message: "Function base64Decode() can not decode: %%%"},
+}
+
+func TestEncodingFunctions(t *testing.T) {
+	verifyFunctions(t, common.DefaultConfiguration().AddHmacKey("pii", []byte("secret")), ENCODING_SERIES,
+		encodingFunctionTestDatas)
+}
+
+func TestEncodingFunctionsNegative(t *testing.T) {
+	verifyFunctionErrors(t, common.DefaultConfiguration().AddHmacKey("pii", []byte("secret")), ENCODING_SERIES,
+		encodingFunctionErrorTestDatas)
+}
+
+func TestHmacUnknownKeyNegative(t *testing.T) {
+	verifyFunctionErrors(t, common.DefaultConfiguration(), ENCODING_SERIES, []functionErrorTestData{
+		{path: "$.users[0].email.hmac('pii')", code: common.CodeJsonPath, message: "Function hmac() found no key named: pii"},
+	})
+}
+
+func TestAddHmacKeyCopiesConfiguration(t *testing.T) {
+	conf := common.DefaultConfiguration()
+	withKey := conf.AddHmacKey("pii", []byte("secret"))
+	if _, ok := conf.HmacKey("pii"); ok {
+		t.Errorf("the key was added to the original configuration")
+	}
+	if _, ok := withKey.AddHmacKey("other", []byte("x")).HmacKey("other"); !ok {
+		t.Errorf("the key was not added to the copy")
+	}
+	if _, ok := withKey.HmacKey("other"); ok {
+		t.Errorf("the key was added to the configuration it was copied from")
+	}
+}
@@ -0,0 +1,47 @@
+package function
+
+import (
+	"github.com/CuiChao512/go-jsonpath/jsonpath/common"
+	"math"
+	"testing"
+)
+
+const MATH_SERIES = "{\"prices\": [2.675, 1.005, 10], \"readings\": [{\"id\": \"a\", \"delta\": -12.5}, {\"id\": \"b\", \"delta\": 4}, " +
+	"{\"id\": \"c\", \"delta\": 11}], \"side\": 9, \"level\": 140}"
+
+var mathFunctionTestDatas = []functionTestData{
+	{path: "$.prices[*].round(2)", expect: []interface{}{2.68, 1.01, float64(10)}},
+	{path: "$.prices.round(1)", expect: []interface{}{2.7, float64(1), float64(10)}},
+	{path: "$.prices[0].round()", expect: float64(3)},
+	{path: "$.readings[?(@.delta.abs() > 10)].id", expect: []interface{}{"a", "c"}},
+	{path: "$.readings[*].delta.floor()", expect: []interface{}{float64(-13), float64(4), float64(11)}},
+	{path: "$.readings[0].delta.ceil()", expect: float64(-12)},
+	{path: "$.side.pow(2)", expect: float64(81)},
+	{path: "$.side.pow(0.5)", expect: float64(3)},
+	{path: "$.prices[2].pow(-2)", expect: 0.01},
+	{path: "$.level.pow(100)", expect: math.Pow(140, 100)},
+	{path: "$.side.sqrt()", expect: float64(3)},
+	{path: "$.level.clamp(0, 100)", expect: float64(100)},
+	{path: "$.readings[*].delta.clamp(0, 10)", expect: []interface{}{float64(0), float64(4), float64(10)}},
+}
+
+var mathFunctionErrorTestDatas = []functionErrorTestData{
+	{path: "$.readings[0].delta.sqrt()", code: common.CodeJsonPath, message: "Function sqrt() has no result that is a number"},
+	{path: "$.side.pow(100000000)", code: common.CodeJsonPath, message: "Function pow() has no result that is a number"},
+	{path: "$.side.pow(64).pow(64)", code: common.CodeJsonPath, message: "Function pow() has no result that is a number"},
+	{path: "$.side.pow(400)", code: common.CodeJsonPath, message: "Function pow() has no result that is a number"},
+	{path: "$.readings[0].abs()", code: common.CodeJsonPath,
+		message: "Function abs() expects a number, got: map[delta:-12.5 id:a]"},
+	{path: "$.level.clamp(10, 0)", code: common.CodeInvalidPath,
+		message: "Function clamp() expects a minimum that is not greater than the maximum"},
+	{path: "$.level.clamp(1)", code: common.CodeInvalidPath, message: "Function clamp() is missing parameter 2"},
+	{path: "$.level.round('x')", code: common.CodeInvalidPath, message: "Function round() expects an integer as parameter 1"},
+}
+
+func TestMathFunctions(t *testing.T) {
+	verifyFunctions(t, common.DefaultConfiguration(), MATH_SERIES, mathFunctionTestDatas)
+}
+
+func TestMathFunctionsNegative(t *testing.T) {
+	verifyFunctionErrors(t, common.DefaultConfiguration(), MATH_SERIES, mathFunctionErrorTestDatas)
+}
@@ -94,7 +94,7 @@ func TestAppendNumber(t *testing.T) {
 
 func TestAppendTextAndNumberThenSum(t *testing.T) {
 	conf := common.DefaultConfiguration()
-	result, err := verifyMathFunction(conf, "$.numbers.append(\"0\", \"11\").sum()", 55.0)
+	result, err := verifyMathFunction(conf, "$.numbers.append(\"0\", \"11\").sum()", 66.0)
 	if err != nil {
 		t.Errorf("error : %s", err)
 	} else if !result {
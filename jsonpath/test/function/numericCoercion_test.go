@@ -0,0 +1,77 @@
+package function
+
+import (
+	"encoding/json"
+	"github.com/CuiChao512/go-jsonpath/jsonpath"
+	"github.com/CuiChao512/go-jsonpath/jsonpath/common"
+	"reflect"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func providerNumbers() map[string]interface{} {
+	return map[string]interface{}{
+		"mixed":   []interface{}{int64(1), uint(2), json.Number("3.5"), decimal.NewFromFloat(1.5), "2", "x", nil},
+		"numbers": []interface{}{int64(1), uint8(2), json.Number("3")},
+		"items": []interface{}{
+			map[string]interface{}{"n": "a", "v": int64(5)},
+			map[string]interface{}{"n": "b", "v": json.Number("12")},
+			map[string]interface{}{"n": "c", "v": decimal.NewFromInt(20)},
+		},
+	}
+}
+
+var numericCoercionTestDatas = []functionTestData{
+	{path: "$.mixed.sum()", expect: float64(10)},
+	{path: "$.mixed.avg()", expect: float64(2)},
+	{path: "$.mixed.count()", expect: 5},
+	{path: "$.mixed.max()", expect: 3.5},
+	{path: "$.numbers.sum()", expect: float64(6)},
+	{path: "$.mixed.abs()", expect: []interface{}{float64(1), float64(2), 3.5, 1.5, float64(2)}},
+	{path: "$.numbers.pow(2)", expect: []interface{}{float64(1), float64(4), float64(9)}},
+	{path: "$.items[?(@.v.clamp(0, 10) == 10)].n", expect: []interface{}{"b", "c"}},
+	{path: "$.items[?(@.v > 10)].n", expect: []interface{}{"b", "c"}},
+	{path: "$.items[?(@.v * 2 == 10)].n", expect: []interface{}{"a"}},
+	{path: "$.items[?(@.v in [5, 20])].n", expect: []interface{}{"a", "c"}},
+}
+
+func readProviderNumbers(conf *common.Configuration, path string) (interface{}, error) {
+	documentContext, err := jsonpath.CreateParseContextImplByConfiguration(conf).ParseAny(providerNumbers())
+	if err != nil {
+		return nil, err
+	}
+	return documentContext.Read(path)
+}
+
+func TestNumericCoercion(t *testing.T) {
+	conf := common.DefaultConfiguration()
+	for _, data := range numericCoercionTestDatas {
+		result, err := readProviderNumbers(conf, data.path)
+		if err != nil {
+			t.Errorf("%s: %s", data.path, err)
+		} else if !reflect.DeepEqual(conf.JsonProvider().Unwrap(result), data.expect) {
+			t.Errorf("%s: expected %v, got %v", data.path, data.expect, result)
+		}
+	}
+}
+
+func TestStrictNumbers(t *testing.T) {
+	conf := common.DefaultConfiguration().AddOptions(common.OPTION_STRICT_NUMBERS)
+	result, err := readProviderNumbers(conf, "$.numbers.sum()")
+	if err != nil || result != float64(6) {
+		t.Errorf("expected 6, got %v %v", result, err)
+	}
+}
+
+func TestStrictNumbersNegative(t *testing.T) {
+	conf := common.DefaultConfiguration().AddOptions(common.OPTION_STRICT_NUMBERS)
+	datas := []functionErrorTestData{
+		{path: "$.mixed.sum()", code: common.CodeJsonPath, message: "Aggregation function expects numbers, got: x"},
+		{path: "$.mixed.round()", code: common.CodeJsonPath, message: "Function round() expects numbers, got: x"},
+	}
+	for _, data := range datas {
+		_, err := readProviderNumbers(conf, data.path)
+		assertFunctionError(t, data, err)
+	}
+}
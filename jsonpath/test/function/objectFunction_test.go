@@ -0,0 +1,41 @@
+package function
+
+import (
+	"github.com/CuiChao512/go-jsonpath/jsonpath/common"
+	"testing"
+)
+
+const OBJECT_SERIES = "{\"user\": {\"name\": \"joe\", \"age\": 30, \"secret\": \"x\"}, \"defaults\": {\"age\": 18, \"role\": \"guest\"}, " +
+	"\"pairs\": [[\"a\", 1], [\"b\", 2]], \"users\": [{\"name\": \"ann\", \"secret\": \"y\"}, {\"name\": \"bob\", \"secret\": \"z\"}]}"
+
+var objectFunctionTestDatas = []functionTestData{
+	{path: "$.user.values()", expect: []interface{}{float64(30), "joe", "x"}},
+	{path: "$.user.entries()[0]", expect: map[string]interface{}{"key": "age", "value": float64(30)}},
+	{path: "$.user.entries().fromEntries()", expect: map[string]interface{}{"name": "joe", "age": float64(30), "secret": "x"}},
+	{path: "$.pairs.fromEntries()", expect: map[string]interface{}{"a": float64(1), "b": float64(2)}},
+	{path: "$.user.pick('name', 'age', 'missing')", expect: map[string]interface{}{"name": "joe", "age": float64(30)}},
+	{path: "$.user.omit('secret')", expect: map[string]interface{}{"name": "joe", "age": float64(30)}},
+	{path: "$.users[*].omit('secret')", expect: []interface{}{map[string]interface{}{"name": "ann"}, map[string]interface{}{"name": "bob"}}},
+	{path: "$.defaults.merge($.user)", expect: map[string]interface{}{"name": "joe", "age": float64(30), "secret": "x", "role": "guest"}},
+	{path: "$.user.merge($.defaults).age", expect: float64(18)},
+	{path: "$.user.merge({\"age\": 31}).omit('secret').values()", expect: []interface{}{float64(31), "joe"}},
+}
+
+var objectFunctionErrorTestDatas = []functionErrorTestData{
+	{path: "$.pairs.values()", code: common.CodeJsonPath,
+		message: "Function values() expects an object, got: [[a 1] [b 2]]"},
+	{path: "$.defaults.fromEntries()", code: common.CodeJsonPath,
+		message: "Function fromEntries() expects an array, got: map[age:18 role:guest]"},
+	{path: "$.users.fromEntries()", code: common.CodeJsonPath,
+		message: "Function fromEntries() expects entries with a string key and a value, got: map[name:ann secret:y]"},
+	{path: "$.user.merge(1)", code: common.CodeInvalidPath, message: "Function merge() expects an object as parameter 1"},
+	{path: "$.user.pick(1)", code: common.CodeInvalidPath, message: "Function pick() expects a string as parameter 1"},
+}
+
+func TestObjectFunctions(t *testing.T) {
+	verifyFunctions(t, common.DefaultConfiguration(), OBJECT_SERIES, objectFunctionTestDatas)
+}
+
+func TestObjectFunctionsNegative(t *testing.T) {
+	verifyFunctionErrors(t, common.DefaultConfiguration(), OBJECT_SERIES, objectFunctionErrorTestDatas)
+}
@@ -0,0 +1,46 @@
+package function
+
+import (
+	"github.com/CuiChao512/go-jsonpath/jsonpath/common"
+	"testing"
+)
+
+const STATS_SERIES = "{\"empty\": [], \"n\": [1, 2, 3, 4, 5, 6, 7, 8, 9, 10], \"m\": [3, 1, 3, 2, 2], " +
+	"\"hosts\": [{\"name\": \"a\", \"latencies\": [100, 200, 300]}, {\"name\": \"b\", \"latencies\": [200, 260, 900]}], " +
+	"\"svc\": {\"x\": {\"ms\": 10}, \"y\": {\"ms\": 30}, \"z\": {\"ms\": 20}}}"
+
+var statsFunctionTestDatas = []functionTestData{
+	{path: "$.n.median()", expect: 5.5},
+	{path: "$.m.median()", expect: float64(2)},
+	{path: "$.n.percentile(95)", expect: 9.55},
+	{path: "$.n.percentile(0)", expect: float64(1)},
+	{path: "$.n.percentile(50, 11)", expect: float64(6)},
+	{path: "$.m.mode()", expect: float64(2)},
+	{path: "$.n.count()", expect: 10},
+	{path: "$.empty.count()", expect: 0},
+	{path: "$.n.variance()", expect: 8.25},
+	{path: "$.m.countDistinct()", expect: 3},
+	{path: "$..ms.median()", expect: float64(20)},
+	{path: "$..latencies[*].count()", expect: 6},
+	{path: "$.hosts[?(@.latencies.median() == 200)].name", expect: []interface{}{"a"}},
+	{path: "$.hosts[?(@.latencies.percentile(99) > 300)].name", expect: []interface{}{"b"}},
+}
+
+var statsFunctionErrorTestDatas = []functionErrorTestData{
+	{path: "$.n.percentile(101)", code: common.CodeInvalidPath,
+		message: "Function percentile() expects a number from 0 to 100, got: 101"},
+	{path: "$.n.percentile('x')", code: common.CodeInvalidPath,
+		message: "Function percentile() expects a number from 0 to 100, got: x"},
+	{path: "$.empty.median()", code: common.CodeJsonPath,
+		message: "Aggregation function attempted to calculate value using empty array"},
+	{path: "$.hosts.mode()", code: common.CodeJsonPath,
+		message: "Aggregation function attempted to calculate value using empty array"},
+}
+
+func TestStatsFunctions(t *testing.T) {
+	verifyFunctions(t, common.DefaultConfiguration(), STATS_SERIES, statsFunctionTestDatas)
+}
+
+func TestStatsFunctionsNegative(t *testing.T) {
+	verifyFunctionErrors(t, common.DefaultConfiguration(), STATS_SERIES, statsFunctionErrorTestDatas)
+}
@@ -0,0 +1,60 @@
+package function
+
+import (
+	"github.com/CuiChao512/go-jsonpath/jsonpath/common"
+	"testing"
+)
+
+const STRING_SERIES = "{\"name\": \"  Hello World \", \"csv\": \"x,y,z\", \"tags\": [\"a\", \"b\"], \"users\": [" +
+	"{\"email\": \"Ann@CORP.com\", \"name\": \"Ann\"}, {\"email\": \"bob@home.org\", \"name\": \"Bob\"}]}"
+
+var stringFunctionTestDatas = []functionTestData{
+	{path: "$.name.lower()", expect: "  hello world "},
+	{path: "$.name.upper()", expect: "  HELLO WORLD "},
+	{path: "$.name.trim()", expect: "Hello World"},
+	{path: "$.name.trim().substring(6)", expect: "World"},
+	{path: "$.name.trim().substring(0, 5)", expect: "Hello"},
+	{path: "$.name.trim().substring(-5, 3)", expect: "Wor"},
+	{path: "$.name.trim().substring(4, 100)", expect: "o World"},
+	{path: "$.name.replace('o', '0')", expect: "  Hell0 W0rld "},
+	{path: "$.name.replace(\"(l+)\", \"[$1]\")", expect: "  He[ll]o Wor[l]d "},
+	{path: "$.name.replace('o', '(')", expect: "  Hell( W(rld "},
+	{path: "$.name.trim().replace('[ ]', ')')", expect: "Hello)World"},
+	{path: "$.name.trim().replace(\"o\", 'a)').replace('[)]', '(')", expect: "Hella( Wa(rld"},
+	{path: "$.csv.split(',').join(\"', '\")", expect: "x', 'y', 'z"},
+	{path: "$.users[?(@.name.replace('n', ')') == 'A))')].name", expect: []interface{}{"Ann"}},
+	{path: "$.csv.split(',')", expect: []interface{}{"x", "y", "z"}},
+	{path: "$.tags.join(' | ')", expect: "a | b"},
+	{path: "$.tags.join()", expect: "a,b"},
+	{path: "$.csv.split(',').join(';')", expect: "x;y;z"},
+	{path: "$.name.trim().startsWith('Hell')", expect: true},
+	{path: "$.name.endsWith(\"x\")", expect: false},
+	{path: "$.name.indexOf('World')", expect: 8},
+	{path: "$.name.indexOf('none')", expect: -1},
+	{path: "$.users[*].name.upper()", expect: []interface{}{"ANN", "BOB"}},
+	{path: "$.users[?(@.email.lower() =~ /@corp\\.com$/)].name", expect: []interface{}{"Ann"}},
+	{path: "$.users[?(@.name.startsWith('B'))].name", expect: []interface{}{"Bob"}},
+	{path: "$.users[?(!@.name.startsWith('B'))].name", expect: []interface{}{"Ann"}},
+	{path: "$.users[?(@.email.substring(0, 3) == 'bob')].name", expect: []interface{}{"Bob"}},
+	{path: "$.users[?(@.email.split('@').join(' at ') == 'bob at home.org')].name", expect: []interface{}{"Bob"}},
+	{path: "$.users[?(@.name.indexOf('n') > 0)].name", expect: []interface{}{"Ann"}},
+}
+
+var stringFunctionErrorTestDatas = []functionErrorTestData{
+	{path: "$.tags.lower()", code: common.CodeJsonPath, message: "Function lower() expects a string, got: [a b]"},
+	{path: "$.tags.split(',')", code: common.CodeJsonPath, message: "Function split() expects a string, got: [a b]"},
+	{path: "$.name.join()", code: common.CodeJsonPath, message: "Function join() expects an array, got:   Hello World "},
+	{path: "$.name.substring('x')", code: common.CodeInvalidPath,
+		message: "Function substring() expects an integer as parameter 1"},
+	{path: "$.name.substring(0, -1)", code: common.CodeInvalidPath,
+		message: "Function substring() expects a length that is not negative"},
+	{path: "$.name.replace('(')", code: common.CodeInvalidPath, message: "Function replace() is missing parameter 2"},
+}
+
+func TestStringFunctions(t *testing.T) {
+	verifyFunctions(t, common.DefaultConfiguration(), STRING_SERIES, stringFunctionTestDatas)
+}
+
+func TestStringFunctionsNegative(t *testing.T) {
+	verifyFunctionErrors(t, common.DefaultConfiguration(), STRING_SERIES, stringFunctionErrorTestDatas)
+}
@@ -0,0 +1,39 @@
+package function
+
+import (
+	"github.com/CuiChao512/go-jsonpath/jsonpath/common"
+	"testing"
+)
+
+const TYPE_SERIES = "{\"items\": [{\"n\": \"a\", \"qty\": \"7\", \"on\": \"true\", \"tags\": [\"x\"]}, " +
+	"{\"n\": \"b\", \"qty\": 3, \"on\": false, \"tags\": null}, {\"n\": \"c\", \"qty\": \"lots\", \"on\": 1, \"tags\": {\"k\": 1}}]}"
+
+var typeFunctionTestDatas = []functionTestData{
+	{path: "$.items[0].qty.toNumber()", expect: float64(7)},
+	{path: "$.items[1].qty.toString()", expect: "3"},
+	{path: "$.items[2].tags.toString()", expect: "{\"k\":1}"},
+	{path: "$.items[0].on.toBoolean()", expect: true},
+	{path: "$.items[*].qty.type()", expect: []interface{}{"string", "number", "string"}},
+	{path: "$.items[*].tags.type()", expect: []interface{}{"array", "null", "object"}},
+	{path: "$.items[?(@.qty.toNumber() > 5)].n", expect: []interface{}{"a"}},
+	{path: "$.items[?(@.on.toBoolean() == true)].n", expect: []interface{}{"a", "c"}},
+	{path: "$.items[?(@.qty.type() == 'string')].n", expect: []interface{}{"a", "c"}},
+	{path: "$.items[?(@.qty.isNumber())].n", expect: []interface{}{"b"}},
+	{path: "$.items[?(@.qty.isString())].n", expect: []interface{}{"a", "c"}},
+	{path: "$.items[?(@.tags.isArray())].n", expect: []interface{}{"a"}},
+	{path: "$.items[?(@.tags.isObject())].n", expect: []interface{}{"c"}},
+}
+
+var typeFunctionErrorTestDatas = []functionErrorTestData{
+	{path: "$.items[2].qty.toNumber()", code: common.CodeJsonPath, message: "Function toNumber() can not convert: lots"},
+	{path: "$.items[0].tags.toNumber()", code: common.CodeJsonPath, message: "Function toNumber() can not convert: [x]"},
+	{path: "$.items[2].n.toBoolean()", code: common.CodeJsonPath, message: "Function toBoolean() can not convert: c"},
+}
+
+func TestTypeFunctions(t *testing.T) {
+	verifyFunctions(t, common.DefaultConfiguration(), TYPE_SERIES, typeFunctionTestDatas)
+}
+
+func TestTypeFunctionsNegative(t *testing.T) {
+	verifyFunctionErrors(t, common.DefaultConfiguration(), TYPE_SERIES, typeFunctionErrorTestDatas)
+}
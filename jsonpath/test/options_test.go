@@ -0,0 +1,24 @@
+package test
+
+import (
+	"github.com/CuiChao512/go-jsonpath/jsonpath"
+	"github.com/CuiChao512/go-jsonpath/jsonpath/common"
+	"reflect"
+	"testing"
+)
+
+func TestAlwaysReturnListWrapsDefinitePaths(t *testing.T) {
+	conf := common.DefaultConfiguration().AddOptions(common.OPTION_ALWAYS_RETURN_LIST)
+	documentContext, err := jsonpath.CreateParseContextImplByConfiguration(conf).ParseString(TestJsonDocument)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := documentContext.Read("$.store.book[0].author")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect := []interface{}{"Nigel Rees"}
+	if !reflect.DeepEqual(result, expect) {
+		t.Errorf("expected %v, got %v", expect, result)
+	}
+}
@@ -0,0 +1,116 @@
+package test
+
+import (
+	"github.com/CuiChao512/go-jsonpath/jsonpath"
+	"github.com/CuiChao512/go-jsonpath/jsonpath/common"
+	"reflect"
+	"testing"
+)
+
+var parentTestDocument = "{\n" +
+	"  \"orders\": [\n" +
+	"    {\"id\": 1, \"items\": [{\"sku\": \"A\"}, {\"sku\": \"X\"}]},\n" +
+	"    {\"id\": 2, \"items\": [{\"sku\": \"B\"}]}\n" +
+	"  ],\n" +
+	"  \"groups\": {\"a\": [1, 2], \"b\": [3]}\n" +
+	"}"
+
+var firstOrder = map[string]interface{}{
+	"id":    float64(1),
+	"items": []interface{}{map[string]interface{}{"sku": "A"}, map[string]interface{}{"sku": "X"}},
+}
+
+type parentTestData struct {
+	path   string
+	expect interface{}
+}
+
+var parentTestDatas = []parentTestData{
+	{path: "$.orders[*].items[?(@.sku == 'X')]^^", expect: []interface{}{firstOrder}},
+	{path: "$.orders[0].items^", expect: firstOrder},
+	{path: "$['orders'][0]['items']^.id", expect: float64(1)},
+	{path: "$.orders[*].id^.items[0].sku", expect: []interface{}{"A", "B"}},
+	{path: "$.orders[*].items[*]^^.id", expect: []interface{}{float64(1), float64(2)}},
+	{path: "$..[?(@.sku == 'B')]^^^^.groups.b", expect: []interface{}{[]interface{}{float64(3)}}},
+	{path: "$.orders[-1].items^.id", expect: float64(2)},
+	{path: "$.groups.*[?(@parentProperty == 'a')]", expect: []interface{}{float64(1), float64(2)}},
+	{path: "$.orders[*].items[?(@parent[1])].sku", expect: []interface{}{"A", "X"}},
+	{path: "$.orders[?(@.id == @parent[1].id)].id", expect: []interface{}{float64(2)}},
+	{path: "$.orders[?(@parentProperty == 'orders')].id", expect: []interface{}{float64(1), float64(2)}},
+	{path: "$.orders[?(@parent.groups)].id", expect: []interface{}{}},
+	{path: "$..items[?(@.sku == 'X' && @parentProperty == 'items')].sku", expect: []interface{}{"X"}},
+}
+
+func Test_parent_selector(t *testing.T) {
+	for _, data := range parentTestDatas {
+		documentContext, err := jsonpath.ParseString(parentTestDocument)
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+		result, err := documentContext.Read(data.path)
+		if err != nil {
+			t.Errorf("%s: %s", data.path, err.Error())
+			continue
+		}
+		if !reflect.DeepEqual(result, data.expect) {
+			t.Errorf("%s: expected %v, got %v", data.path, data.expect, result)
+		}
+	}
+}
+
+func Test_parent_selector_paths(t *testing.T) {
+	conf := common.DefaultConfiguration().AddOptions(common.OPTION_AS_PATH_LIST)
+	documentContext, err := jsonpath.CreateParseContextImplByConfiguration(conf).ParseString(parentTestDocument)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	result, err := documentContext.Read("$.orders[*].items[?(@.sku == 'X')]^^")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	expect := []interface{}{"$['orders'][0]"}
+	if !reflect.DeepEqual(result, expect) {
+		t.Errorf("expected %v, got %v", expect, result)
+	}
+}
+
+func Test_parent_selector_quoted_names(t *testing.T) {
+	// both c have the evaluated path $['a']['b']['c']
+	documentContext, err := jsonpath.ParseString(`{"a']['b": {"c": 1}, "a": {"b": {"c": 2}}}`)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	result, err := documentContext.Read("$..c^.c")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if values, _ := result.([]interface{}); len(values) != 2 || values[0] == values[1] {
+		t.Errorf("expected the parents of both properties, got %v", result)
+	}
+	result, err = documentContext.Read("$.*.*^~")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if names, _ := result.([]interface{}); !reflect.DeepEqual(sortedPropertyNames(names), []string{"a", "a']['b"}) {
+		t.Errorf("expected the names of both members, got %v", result)
+	}
+}
+
+func Test_parent_selector_path_set(t *testing.T) {
+	documentContext, err := jsonpath.ParseString(parentTestDocument)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	set, err := jsonpath.CompilePathSet("$.orders[0].items^.id", "$.groups.a[0]~")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	results, err := documentContext.ReadPathSet(set)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	expect := map[string]interface{}{"$.orders[0].items^.id": float64(1), "$.groups.a[0]~": 0}
+	if !reflect.DeepEqual(results, expect) {
+		t.Errorf("expected %v, got %v", expect, results)
+	}
+}
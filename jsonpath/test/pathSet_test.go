@@ -0,0 +1,122 @@
+package test
+
+import (
+	"errors"
+	"github.com/CuiChao512/go-jsonpath/jsonpath"
+	"github.com/CuiChao512/go-jsonpath/jsonpath/common"
+	"reflect"
+	"testing"
+)
+
+var pathSetTestPaths = []string{
+	"$",
+	"$.store.book[0].title",
+	"$.store.book[*].author",
+	"$.store.bicycle.color",
+	"$.store.bicycle.display-price",
+	"$..isbn",
+	"$..author",
+	"$.store..price",
+	"$.store.book[?(@.display-price > 10)].title",
+	"$.store.book.length()",
+	"$..display-price.sum()",
+	"$.string-property",
+	"$.store.book[*].isbn",
+}
+
+func TestPathSetReadsLikeRead(t *testing.T) {
+	ctx, err := getParseContextUsingDefaultConf().ParseString(TestJsonDocument)
+	if err != nil {
+		t.Fatal(err)
+	}
+	set, err := jsonpath.CompilePathSet(pathSetTestPaths...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	results, err := ctx.ReadPathSet(set)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range pathSetTestPaths {
+		expected, err := ctx.Read(path)
+		if err != nil {
+			t.Fatalf("%s: %v", path, err)
+		}
+		if !reflect.DeepEqual(results[path], expected) {
+			t.Errorf("%s: expected %v, got %v", path, expected, results[path])
+		}
+	}
+}
+
+func TestPathSetKeepsReadingAfterAFailedPath(t *testing.T) {
+	ctx, err := getParseContextUsingDefaultConf().ParseString(TestJsonDocument)
+	if err != nil {
+		t.Fatal(err)
+	}
+	set, err := jsonpath.CompilePathSet("$.store.missing.color", "$.store.bicycle.color")
+	if err != nil {
+		t.Fatal(err)
+	}
+	results, err := ctx.ReadPathSet(set)
+	var notFound *common.PathNotFoundError
+	if !errors.As(err, &notFound) || notFound.Path != "$['store']['missing']['color']" {
+		t.Errorf("expected a path not found error of the missing path, got %v", err)
+	}
+	if _, ok := results["$.store.missing.color"]; ok {
+		t.Errorf("the missing path has a result")
+	}
+	if results["$.store.bicycle.color"] != "red" {
+		t.Errorf("expected red, got %v", results["$.store.bicycle.color"])
+	}
+}
+
+func TestPathSetIsNotReadInRFC9535Mode(t *testing.T) {
+	conf := common.DefaultConfiguration().AddOptions(common.OPTION_RFC9535)
+	ctx, err := jsonpath.CreateParseContextImplByConfiguration(conf).ParseString(TestJsonDocument)
+	if err != nil {
+		t.Fatal(err)
+	}
+	set, err := jsonpath.CompilePathSet("$.store.bicycle.color")
+	if err != nil {
+		t.Fatal(err)
+	}
+	results, err := ctx.ReadPathSet(set)
+	if !errors.Is(err, common.ErrInvalidPath) || results != nil {
+		t.Errorf("expected an invalid path error, got %v %v", results, err)
+	}
+}
+
+// countingJsonProvider counts the properties read from the document
+type countingJsonProvider struct {
+	*common.NativeJsonProvider
+	reads map[string]int
+}
+
+func (p *countingJsonProvider) GetMapValue(obj interface{}, key string) interface{} {
+	p.reads[key]++
+	return p.NativeJsonProvider.GetMapValue(obj, key)
+}
+
+func TestPathSetReadsSharedPrefixesOnce(t *testing.T) {
+	provider := &countingJsonProvider{NativeJsonProvider: &common.NativeJsonProvider{}, reads: map[string]int{}}
+	configuration := common.CreateConfiguration(provider, nil, &common.NativeMappingProvider{})
+	document, err := provider.Parse(TestJsonDocument)
+	if err != nil {
+		t.Fatal(err)
+	}
+	set, err := jsonpath.CompilePathSet("$.store.bicycle.color", "$.store.bicycle.display-price", "$.store.book[0].title",
+		"$.store..isbn", "$.store..author")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = set.Read(document, configuration); err != nil {
+		t.Fatal(err)
+	}
+	// the walk of the scans reads every property of $.store once more
+	if provider.reads["store"] != 1 || provider.reads["bicycle"] != 2 {
+		t.Errorf("expected the paths to share the reads of store and bicycle, got %v", provider.reads)
+	}
+	if provider.reads["book"] != 2 {
+		t.Errorf("expected the scans to share one walk, got %v", provider.reads)
+	}
+}
@@ -0,0 +1,97 @@
+package test
+
+import (
+	"errors"
+	"github.com/CuiChao512/go-jsonpath/jsonpath/common"
+	"github.com/CuiChao512/go-jsonpath/jsonpath/filter"
+	"reflect"
+	"testing"
+)
+
+type pathSyntaxErrorTestData struct {
+	path     string
+	offset   int
+	column   int
+	token    string
+	expected []string
+}
+
+var pathSyntaxErrorTestDatas = []pathSyntaxErrorTestData{
+	{path: "$X", offset: 1, column: 2, token: "X", expected: []string{".", "["}},
+	{path: "store.book[x", offset: 10, column: 11, token: "[", expected: []string{"?", "'", "0-9", "*"}},
+	{path: "$.a.", offset: 3, column: 4, token: "."},
+	{path: "$.a[?(@.i == 5 @.i == 8)]", offset: 15, column: 16, token: "@"},
+}
+
+func TestPathSyntaxError(t *testing.T) {
+	for _, data := range pathSyntaxErrorTestDatas {
+		_, err := filter.PathCompile(data.path)
+		if _, ok := err.(*common.InvalidPathError); !ok {
+			t.Errorf("%s: expected an InvalidPathError, got %v", data.path, err)
+			continue
+		}
+		var syntaxError *common.PathSyntaxError
+		if !errors.As(err, &syntaxError) {
+			t.Errorf("%s: expected a PathSyntaxError, got %v", data.path, err)
+			continue
+		}
+		if syntaxError.Offset != data.offset || syntaxError.Line != 1 || syntaxError.Column != data.column ||
+			syntaxError.Token != data.token || !reflect.DeepEqual(syntaxError.Expected, data.expected) {
+			t.Errorf("%s: unexpected %+v", data.path, *syntaxError)
+		}
+		if common.ErrorCodeOf(err) != common.CodePathSyntax || !errors.Is(err, common.ErrInvalidPath) {
+			t.Errorf("%s: unexpected code %s", data.path, common.ErrorCodeOf(err))
+		}
+	}
+}
+
+func TestPathSyntaxErrorOfFilterIsPropagated(t *testing.T) {
+	_, err := filter.PathCompile("$.a[?(@.foo == 1 2)].b")
+	var syntaxError *common.PathSyntaxError
+	if !errors.As(err, &syntaxError) {
+		t.Fatalf("expected a PathSyntaxError, got %v", err)
+	}
+	if syntaxError.Message != "Expected character: )" {
+		t.Errorf("the error of the filter was not propagated: %s", syntaxError.Message)
+	}
+}
+
+func TestPathSyntaxErrorPretty(t *testing.T) {
+	_, err := filter.PathCompile("$X")
+	var syntaxError *common.PathSyntaxError
+	if !errors.As(err, &syntaxError) {
+		t.Fatalf("expected a PathSyntaxError, got %v", err)
+	}
+	expected := "Illegal character at position 1 expected '.' or '[' at line 1, column 2\n" +
+		"$X\n" +
+		" ^\n" +
+		"Expected: ., ["
+	if pretty := syntaxError.Pretty(); pretty != expected {
+		t.Errorf("unexpected rendering:\n%s", pretty)
+	}
+}
+
+func TestPathSyntaxErrorLineAndColumn(t *testing.T) {
+	syntaxError := common.CreatePathSyntaxError("Unexpected", "$.a\n  .b[x", 9)
+	if syntaxError.Line != 2 || syntaxError.Column != 6 || syntaxError.Token != "x" {
+		t.Errorf("unexpected %+v", *syntaxError)
+	}
+	if pretty := syntaxError.Pretty(); pretty != "Unexpected at line 2, column 6\n  .b[x\n     ^" {
+		t.Errorf("unexpected rendering:\n%s", pretty)
+	}
+}
+
+func TestPathSyntaxErrorIsStable(t *testing.T) {
+	_, err := filter.Compile("[?(@.a == 1 2)]")
+	if _, ok := err.(*common.InvalidPathError); !ok {
+		t.Fatalf("expected an InvalidPathError, got %v", err)
+	}
+	var first, second *common.InvalidPathError
+	if !errors.As(err, &first) || !errors.As(err, &second) || first != second {
+		t.Errorf("errors.As should find the same InvalidPathError")
+	}
+	var syntaxError *common.PathSyntaxError
+	if !errors.As(err, &syntaxError) || syntaxError.Unwrap() != nil {
+		t.Errorf("unexpected cause %v", errors.Unwrap(err))
+	}
+}
@@ -0,0 +1,107 @@
+package test
+
+import (
+	"github.com/CuiChao512/go-jsonpath/jsonpath"
+	"github.com/CuiChao512/go-jsonpath/jsonpath/common"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+var propertyNameTestDocument = "{\n" +
+	"  \"env\": {\"FEATURE_A\": true, \"FEATURE_B\": false, \"HOME\": \"/root\"},\n" +
+	"  \"store\": {\n" +
+	"    \"book\": [{\"title\": \"Sayings\"}, {\"title\": \"Moby Dick\"}],\n" +
+	"    \"bicycle\": {\"color\": \"red\"}\n" +
+	"  }\n" +
+	"}"
+
+var propertyNameTestDatas = []parentTestData{
+	{path: "$.env.*~", expect: []interface{}{"FEATURE_A", "FEATURE_B", "HOME"}},
+	{path: "$.store.book[*]~", expect: []interface{}{0, 1}},
+	{path: "$.store.bicycle.color~", expect: "color"},
+	{path: "$.store.book[-1]~", expect: 1},
+	{path: "$.store.book[?(@property == 1)].title", expect: []interface{}{"Moby Dick"}},
+	{path: "$.store.book[?(@.title == 'Sayings')]~", expect: []interface{}{0}},
+	{path: "$.store[?(@property =~ /^st/)]~", expect: []interface{}{"store"}},
+	{path: "$.store.book[?(@path == \"$['store']['book'][1]\")].title", expect: []interface{}{"Moby Dick"}},
+	{path: "$..[?(@.color)]~", expect: []interface{}{"bicycle"}},
+}
+
+func Test_property_name_selector(t *testing.T) {
+	for _, data := range propertyNameTestDatas {
+		documentContext, err := jsonpath.ParseString(propertyNameTestDocument)
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+		result, err := documentContext.Read(data.path)
+		if err != nil {
+			t.Errorf("%s: %s", data.path, err.Error())
+			continue
+		}
+		if results, ok := result.([]interface{}); ok {
+			// object members are not ordered
+			expects, _ := data.expect.([]interface{})
+			if !reflect.DeepEqual(sortedPropertyNames(results), sortedPropertyNames(expects)) {
+				t.Errorf("%s: expected %v, got %v", data.path, data.expect, result)
+			}
+		} else if !reflect.DeepEqual(result, data.expect) {
+			t.Errorf("%s: expected %v, got %v", data.path, data.expect, result)
+		}
+	}
+}
+
+func sortedPropertyNames(values []interface{}) []string {
+	names := make([]string, 0, len(values))
+	for _, value := range values {
+		names = append(names, common.UtilsToString(value))
+	}
+	sort.Strings(names)
+	return names
+}
+
+func Test_property_name_selector_paths(t *testing.T) {
+	conf := common.DefaultConfiguration().AddOptions(common.OPTION_AS_PATH_LIST)
+	documentContext, err := jsonpath.CreateParseContextImplByConfiguration(conf).ParseString(propertyNameTestDocument)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	result, err := documentContext.Read("$.store.*~")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	expect := []interface{}{"$['store']['bicycle']~", "$['store']['book']~"}
+	if results, _ := result.([]interface{}); !reflect.DeepEqual(sortedPropertyNames(results), sortedPropertyNames(expect)) {
+		t.Errorf("expected %v, got %v", expect, result)
+	}
+}
+
+func Test_property_name_selector_object_members(t *testing.T) {
+	path := "$.env[?(@property =~ /^FEATURE_/)]~"
+	documentContext, err := jsonpath.ParseString(propertyNameTestDocument)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	// the filter tests the env object, which is not a member of env
+	result, err := documentContext.Read(path)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if !reflect.DeepEqual(result, []interface{}{}) {
+		t.Errorf("expected no names without OPTION_FILTER_OBJECT_MEMBERS, got %v", result)
+	}
+
+	conf := common.DefaultConfiguration().AddOptions(common.OPTION_FILTER_OBJECT_MEMBERS)
+	documentContext, err = jsonpath.CreateParseContextImplByConfiguration(conf).ParseString(propertyNameTestDocument)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	result, err = documentContext.Read(path)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	results, _ := result.([]interface{})
+	if expect := []string{"FEATURE_A", "FEATURE_B"}; !reflect.DeepEqual(sortedPropertyNames(results), expect) {
+		t.Errorf("expected %v, got %v", expect, result)
+	}
+}
@@ -0,0 +1,133 @@
+package test
+
+import (
+	"github.com/CuiChao512/go-jsonpath/jsonpath"
+	"github.com/CuiChao512/go-jsonpath/jsonpath/common"
+	"github.com/CuiChao512/go-jsonpath/jsonpath/rfc9535"
+	"reflect"
+	"testing"
+)
+
+var rfc9535Document = "{\n" +
+	"  \"o\": {\"j\": 1, \"k\": 2},\n" +
+	"  \"a\": [5, 3, [{\"j\": 4}, {\"k\": 6}]],\n" +
+	"  \"e\": [\"a\", \"b\", \"c\", \"d\", \"e\", \"f\", \"g\"],\n" +
+	"  \"n\": null,\n" +
+	"  \"books\": [{\"price\": 8, \"title\": \"abc\"}, {\"price\": 12, \"title\": \"b\\nc\"}, {\"title\": \"xyz\"}]\n" +
+	"}"
+
+type rfc9535TestData struct {
+	query  string
+	expect []interface{}
+}
+
+var rfc9535TestDatas = []rfc9535TestData{
+	{query: "$.o.j", expect: []interface{}{float64(1)}},
+	{query: "$['o']['k']", expect: []interface{}{float64(2)}},
+	{query: "$.missing", expect: []interface{}{}},
+	{query: "$.n", expect: []interface{}{nil}},
+	{query: "$.o.*", expect: []interface{}{float64(1), float64(2)}},
+	{query: "$.e[-1]", expect: []interface{}{"g"}},
+	{query: "$.e[7]", expect: []interface{}{}},
+	{query: "$.e[1:3]", expect: []interface{}{"b", "c"}},
+	{query: "$.e[5:1:-2]", expect: []interface{}{"f", "d"}},
+	{query: "$.e[::-3]", expect: []interface{}{"g", "d", "a"}},
+	{query: "$.e[1:5:0]", expect: []interface{}{}},
+	{query: "$.e[0, 0]", expect: []interface{}{"a", "a"}},
+	{query: "$.a..j", expect: []interface{}{float64(4)}},
+	{query: "$.o..*", expect: []interface{}{float64(1), float64(2)}},
+	{query: "$.books[?@.price < 10].title", expect: []interface{}{"abc"}},
+	{query: "$.books[?@.price].title", expect: []interface{}{"abc", "b\nc"}},
+	{query: "$.books[?!@.price].title", expect: []interface{}{"xyz"}},
+	{query: "$.books[?@.price == @.missing].title", expect: []interface{}{"xyz"}},
+	{query: "$.books[?@.missing == @.other].title", expect: []interface{}{"abc", "b\nc", "xyz"}},
+	{query: "$.o[?@ > 1]", expect: []interface{}{float64(2)}},
+	{query: "$.books[?length(@.title) == 3].title", expect: []interface{}{"abc", "b\nc", "xyz"}},
+	{query: "$.books[?match(@.title, 'a.c')].title", expect: []interface{}{"abc"}},
+	{query: "$.books[?match(@.title, 'b.c')].title", expect: []interface{}{}},
+	{query: "$.books[?search(@.title, '[xy]')].title", expect: []interface{}{"xyz"}},
+	{query: "$[?count(@.*) == 2]", expect: []interface{}{map[string]interface{}{"j": float64(1), "k": float64(2)}}},
+	{query: "$.a[?value(@..j) == 4]", expect: []interface{}{[]interface{}{map[string]interface{}{"j": float64(4)}, map[string]interface{}{"k": float64(6)}}}},
+	{query: "$.a[?@ == 5 || (@ == 3 && true == true)]", expect: []interface{}{float64(5), float64(3)}},
+	{query: "$.a[?$.o.j == 1 && @ > 4]", expect: []interface{}{float64(5)}},
+}
+
+func Test_rfc9535_queries(t *testing.T) {
+	conf := common.DefaultConfiguration().AddOptions(common.OPTION_RFC9535)
+	documentContext, err := jsonpath.CreateParseContextImplByConfiguration(conf).ParseString(rfc9535Document)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	for _, data := range rfc9535TestDatas {
+		result, err := documentContext.Read(data.query)
+		if err != nil {
+			t.Errorf("%s: %s", data.query, err.Error())
+			continue
+		}
+		if !reflect.DeepEqual(result, data.expect) {
+			t.Errorf("%s: expected %v, got %v", data.query, data.expect, result)
+		}
+	}
+}
+
+func Test_rfc9535_normalized_paths(t *testing.T) {
+	conf := common.DefaultConfiguration().AddOptions(common.OPTION_RFC9535, common.OPTION_AS_PATH_LIST)
+	documentContext, err := jsonpath.CreateParseContextImplByConfiguration(conf).ParseString(rfc9535Document)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	result, err := documentContext.Read("$..j")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	expect := []interface{}{"$['a'][2][0]['j']", "$['o']['j']"}
+	if !reflect.DeepEqual(result, expect) {
+		t.Errorf("expected %v, got %v", expect, result)
+	}
+}
+
+func Test_rfc9535_invalid_queries(t *testing.T) {
+	invalidQueries := []string{
+		"",
+		" $",
+		"$ ",
+		"$.",
+		"$..",
+		"$. a",
+		"$[01]",
+		"$[-0]",
+		"$[9007199254740992]",
+		"$['a'",
+		"$['\\x']",
+		"$[\"\\'\"]",
+		"$['\\uD800']",
+		"$[?@.a == 01]",
+		"$[?@.* == 1]",
+		"$[?1]",
+		"$[?length(@)]",
+		"$[?count(1) == 1]",
+		"$[?match(@.a) ]",
+		"$[?foo(@.a)]",
+		"$[?True]",
+		"$.a[?@.b == $..c]",
+		"$.a[?@ == [1]]",
+	}
+	for _, query := range invalidQueries {
+		if _, err := rfc9535.Compile(query); err == nil {
+			t.Errorf("%q should be invalid", query)
+		} else if _, ok := err.(*common.InvalidPathError); !ok {
+			t.Errorf("%q should fail with an InvalidPathError", query)
+		}
+	}
+}
+
+func Test_rfc9535_query_can_be_serialized(t *testing.T) {
+	query, err := rfc9535.Compile("$.a[?@.b == 'x' && !@.c].d")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	expect := "$['a'][?@['b'] == 'x' && !@['c']]['d']"
+	if query.String() != expect {
+		t.Errorf("expected %s, got %s", expect, query.String())
+	}
+}
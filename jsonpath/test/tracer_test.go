@@ -0,0 +1,85 @@
+package test
+
+import (
+	"github.com/CuiChao512/go-jsonpath/jsonpath"
+	"github.com/CuiChao512/go-jsonpath/jsonpath/common"
+	"reflect"
+	"testing"
+)
+
+type recordingTracer struct {
+	events []common.TraceEvent
+}
+
+func (r *recordingTracer) Trace(event common.TraceEvent) {
+	r.events = append(r.events, event)
+}
+
+func (r *recordingTracer) kinds(kind common.TraceEventKind) []common.TraceEvent {
+	var events []common.TraceEvent
+	for _, event := range r.events {
+		if event.Kind == kind {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+func TestTracerEvents(t *testing.T) {
+	tracer := &recordingTracer{}
+	configuration := common.DefaultConfiguration().SetTracer(tracer)
+	ctx, err := jsonpath.CreateParseContextImplByConfiguration(configuration).
+		ParseString(`{"items": [{"price": 5, "name": "a"}, {"price": 15, "name": "b"}]}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := ctx.Read("$.items[?(@.price < 10)].name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(result, []interface{}{"a"}) {
+		t.Fatalf("unexpected result %v", result)
+	}
+
+	entered := tracer.kinds(common.TRACE_TOKEN_ENTERED)
+	if len(entered) == 0 || entered[0].Token != "$" || entered[0].Path != "$" {
+		t.Errorf("expected the root token to be entered first, got %v", entered)
+	}
+
+	reads := tracer.kinds(common.TRACE_PROPERTY_READ)
+	if len(reads) != 2 || reads[0].Path != "$['items']" || reads[1].Path != "$['items'][0]['name']" ||
+		reads[1].Value != "a" {
+		t.Errorf("unexpected property reads %v", reads)
+	}
+
+	predicates := tracer.kinds(common.TRACE_PREDICATE_EVALUATED)
+	if len(predicates) != 2 {
+		t.Fatalf("expected a predicate event for each item, got %v", predicates)
+	}
+	if predicates[0].Path != "$['items'][0]" || !predicates[0].Result || predicates[0].Token != "[?]" {
+		t.Errorf("unexpected event for the first item %v", predicates[0])
+	}
+	if predicates[1].Path != "$['items'][1]" || predicates[1].Result {
+		t.Errorf("unexpected event for the second item %v", predicates[1])
+	}
+
+	added := tracer.kinds(common.TRACE_RESULT_ADDED)
+	if len(added) != 1 || added[0].Path != "$['items'][0]['name']" || added[0].Value != "a" {
+		t.Errorf("unexpected results %v", added)
+	}
+}
+
+func TestTracerTracesDefinitePaths(t *testing.T) {
+	tracer := &recordingTracer{}
+	configuration := common.DefaultConfiguration().SetTracer(tracer)
+	ctx, err := jsonpath.CreateParseContextImplByConfiguration(configuration).ParseString(`{"a": {"b": 1}}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = ctx.Read("$.a.b"); err != nil {
+		t.Fatal(err)
+	}
+	if len(tracer.kinds(common.TRACE_RESULT_ADDED)) != 1 {
+		t.Errorf("a definite path was read without events: %v", tracer.events)
+	}
+}
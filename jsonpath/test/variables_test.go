@@ -0,0 +1,74 @@
+package test
+
+import (
+	"errors"
+	"github.com/CuiChao512/go-jsonpath/jsonpath/common"
+	"reflect"
+	"testing"
+)
+
+var usersDocument = `{"users": [
+	{"id": 1, "name": "ann", "role": "admin"},
+	{"id": 2, "name": "bob", "role": "user"},
+	{"id": 3, "name": "o'neil", "role": "guest"}
+]}`
+
+type variablesTestData struct {
+	path     string
+	vars     map[string]interface{}
+	expected interface{}
+}
+
+var variablesTestDatas = []variablesTestData{
+	{
+		path:     "$.users[?(@.id == $userId && @.role in $roles)].name",
+		vars:     map[string]interface{}{"userId": 1, "roles": []string{"admin", "user"}},
+		expected: []interface{}{"ann"},
+	},
+	{
+		path:     "$.users[?(@.id == $userId && @.role in $roles)].name",
+		vars:     map[string]interface{}{"userId": 2, "roles": []interface{}{"guest"}},
+		expected: []interface{}{},
+	},
+	{
+		path:     "$.users[?(@.name == $name)].id",
+		vars:     map[string]interface{}{"name": "o'neil"},
+		expected: []interface{}{float64(3)},
+	},
+	{
+		path:     "$.users[?(@.name == $name)].id",
+		vars:     map[string]interface{}{"name": "$.users[0].name"},
+		expected: []interface{}{},
+	},
+	{
+		path:     "$.users[?(@.id > $min_id)].name",
+		vars:     map[string]interface{}{"min_id": 1.5},
+		expected: []interface{}{"bob", "o'neil"},
+	},
+}
+
+func TestReadWithVars(t *testing.T) {
+	ctx, err := getParseContextUsingDefaultConf().ParseString(usersDocument)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, data := range variablesTestDatas {
+		result, err := ctx.ReadWithVars(data.path, data.vars)
+		if err != nil {
+			t.Errorf("%s %v: %v", data.path, data.vars, err)
+		} else if !reflect.DeepEqual(result, data.expected) {
+			t.Errorf("%s %v: expected %v, got %v", data.path, data.vars, data.expected, result)
+		}
+	}
+}
+
+func TestReadWithUnboundVariable(t *testing.T) {
+	ctx, err := getParseContextUsingDefaultConf().ParseString(usersDocument)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = ctx.ReadWithVars("$.users[?(@.id == $userId)]", map[string]interface{}{})
+	if !errors.Is(err, common.ErrInvalidPath) {
+		t.Errorf("expected an invalid path error, got %v", err)
+	}
+}